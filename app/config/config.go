@@ -4,6 +4,7 @@ import (
 	"log"
 	"os"
 	"strconv"
+	"strings"
 
 	"github.com/spf13/viper"
 )
@@ -11,6 +12,72 @@ import (
 var (
 	JWT_SECRET string
 	CLD_URL    string
+
+	// token untuk membuat super admin pertama, kosong berarti setup dinonaktifkan
+	SETUP_TOKEN string
+
+	// CIDR reverse proxy dipisah koma yang boleh mengirim X-Forwarded-For,
+	// kosong berarti IP klien diambil langsung dari koneksi
+	TRUSTED_PROXIES string
+
+	// penyimpanan file: cloudinary, local atau s3
+	STORAGE_DRIVER    string
+	STORAGE_LOCAL_DIR string
+	STORAGE_BASE_URL  string
+	S3_ENDPOINT       string
+	S3_ACCESS_KEY     string
+	S3_SECRET_KEY     string
+	S3_BUCKET         string
+	S3_USE_SSL        bool
+
+	// pengirim pesan: fake, whatsapp, email atau gabungan dipisah koma
+	SENDER_DRIVER      string
+	WHATSAPP_API_URL   string
+	WHATSAPP_API_TOKEN string
+	SMTP_HOST          string
+	SMTP_PORT          = 587
+	SMTP_USERNAME      string
+	SMTP_PASSWORD      string
+	SMTP_FROM          string
+
+	// kode OTP: masa berlaku dalam menit, batas percobaan dan jeda kirim ulang dalam detik
+	OTP_EXPIRY          = 10
+	OTP_MAX_ATTEMPTS    = 5
+	OTP_RESEND_INTERVAL = 60
+
+	// batas salah kode OTP per akun dan kegunaan dari semua kode dalam rentang waktu,
+	// lama rentang dan lama kunci dalam menit
+	OTP_MAX_FAILURES   = 10
+	OTP_FAILURE_WINDOW = 60
+	OTP_LOCKOUT        = 60
+
+	// pembatasan login: batas gagal per akun dan per IP, lama kunci awal dan maksimal serta
+	// rentang waktu penghitungan kegagalan, semuanya dalam menit
+	LOGIN_MAX_ATTEMPTS    = 5
+	LOGIN_IP_MAX_ATTEMPTS = 20
+	LOGIN_LOCKOUT         = 1
+	LOGIN_LOCKOUT_MAX     = 60
+	LOGIN_ATTEMPT_WINDOW  = 60
+
+	// masa berlaku access token dalam menit dan refresh token dalam hari
+	ACCESS_TOKEN_EXPIRY  = 15
+	REFRESH_TOKEN_EXPIRY = 30
+
+	// masa berlaku signed URL foto privat dalam menit
+	SIGNED_URL_EXPIRY = 15
+
+	// interval pembersihan foto yang tidak terpakai dalam jam, 0 berarti nonaktif
+	STORAGE_CLEANUP_INTERVAL = 0
+
+	// pemrosesan gambar sebelum upload
+	UPLOAD_MAX_SIZE      int64 = 10 * 1024 * 1024
+	IMAGE_MAX_DIMENSION        = 1600
+	IMAGE_QUALITY              = 80
+	IMAGE_THUMBNAIL_SIZE       = 320
+
+	// biaya penyimpanan gudang, default tanpa biaya
+	STORAGE_FREE_DAYS   = 14
+	STORAGE_FEE_PER_DAY = 0
 )
 
 type AppConfig struct {
@@ -58,6 +125,67 @@ func ReadEnv() *AppConfig {
 		CLD_URL = val
 		isRead = false
 	}
+	if val, found := os.LookupEnv("SETUPTOKEN"); found {
+		SETUP_TOKEN = val
+	}
+	if val, found := os.LookupEnv("TRUSTEDPROXIES"); found {
+		TRUSTED_PROXIES = val
+	}
+	if val, found := os.LookupEnv("STORAGEDRIVER"); found {
+		STORAGE_DRIVER = val
+	}
+	if val, found := os.LookupEnv("STORAGELOCALDIR"); found {
+		STORAGE_LOCAL_DIR = val
+	}
+	if val, found := os.LookupEnv("STORAGEBASEURL"); found {
+		STORAGE_BASE_URL = val
+	}
+	if val, found := os.LookupEnv("S3ENDPOINT"); found {
+		S3_ENDPOINT = val
+	}
+	if val, found := os.LookupEnv("S3ACCESSKEY"); found {
+		S3_ACCESS_KEY = val
+	}
+	if val, found := os.LookupEnv("S3SECRETKEY"); found {
+		S3_SECRET_KEY = val
+	}
+	if val, found := os.LookupEnv("S3BUCKET"); found {
+		S3_BUCKET = val
+	}
+	if val, found := os.LookupEnv("S3USESSL"); found {
+		useSSL, err := strconv.ParseBool(val)
+		if err != nil {
+			log.Fatalf("config S3USESSL tidak valid: %q, harus true atau false", val)
+		}
+		S3_USE_SSL = useSSL
+	}
+	if val, found := os.LookupEnv("SENDERDRIVER"); found {
+		SENDER_DRIVER = val
+	}
+	if val, found := os.LookupEnv("WHATSAPPAPIURL"); found {
+		WHATSAPP_API_URL = val
+	}
+	if val, found := os.LookupEnv("WHATSAPPAPITOKEN"); found {
+		WHATSAPP_API_TOKEN = val
+	}
+	if val, found := os.LookupEnv("SMTPHOST"); found {
+		SMTP_HOST = val
+	}
+	if val, found := os.LookupEnv("SMTPUSERNAME"); found {
+		SMTP_USERNAME = val
+	}
+	if val, found := os.LookupEnv("SMTPPASSWORD"); found {
+		SMTP_PASSWORD = val
+	}
+	if val, found := os.LookupEnv("SMTPFROM"); found {
+		SMTP_FROM = val
+	}
+
+	for _, setting := range numberSettings() {
+		if val, found := os.LookupEnv(setting.name); found {
+			setting.parse(val)
+		}
+	}
 
 	if isRead {
 		viper.AddConfigPath(".")
@@ -72,6 +200,28 @@ func ReadEnv() *AppConfig {
 
 		CLD_URL = viper.GetString("CLDURL")
 		JWT_SECRET = viper.GetString("JWTSECRET")
+		SETUP_TOKEN = viper.GetString("SETUPTOKEN")
+		TRUSTED_PROXIES = viper.GetString("TRUSTEDPROXIES")
+		STORAGE_DRIVER = viper.GetString("STORAGEDRIVER")
+		STORAGE_LOCAL_DIR = viper.GetString("STORAGELOCALDIR")
+		STORAGE_BASE_URL = viper.GetString("STORAGEBASEURL")
+		S3_ENDPOINT = viper.GetString("S3ENDPOINT")
+		S3_ACCESS_KEY = viper.GetString("S3ACCESSKEY")
+		S3_SECRET_KEY = viper.GetString("S3SECRETKEY")
+		S3_BUCKET = viper.GetString("S3BUCKET")
+		S3_USE_SSL = viper.GetBool("S3USESSL")
+		SENDER_DRIVER = viper.GetString("SENDERDRIVER")
+		WHATSAPP_API_URL = viper.GetString("WHATSAPPAPIURL")
+		WHATSAPP_API_TOKEN = viper.GetString("WHATSAPPAPITOKEN")
+		SMTP_HOST = viper.GetString("SMTPHOST")
+		SMTP_USERNAME = viper.GetString("SMTPUSERNAME")
+		SMTP_PASSWORD = viper.GetString("SMTPPASSWORD")
+		SMTP_FROM = viper.GetString("SMTPFROM")
+		for _, setting := range numberSettings() {
+			if viper.IsSet(setting.name) {
+				setting.parse(viper.GetString(setting.name))
+			}
+		}
 		app.DB_USERNAME = viper.Get("DBUSER").(string)
 		app.DB_PASSWORD = viper.Get("DBPASS").(string)
 		app.DB_HOSTNAME = viper.Get("DBHOST").(string)
@@ -79,5 +229,70 @@ func ReadEnv() *AppConfig {
 		app.DB_NAME = viper.Get("DBNAME").(string)
 	}
 
+	if LOGIN_LOCKOUT_MAX < LOGIN_LOCKOUT {
+		log.Fatalf("config LOGINLOCKOUTMAX (%d) tidak boleh lebih kecil dari LOGINLOCKOUT (%d)", LOGIN_LOCKOUT_MAX, LOGIN_LOCKOUT)
+	}
+
+	if STORAGE_DRIVER == "" {
+		STORAGE_DRIVER = "cloudinary"
+	}
+	if STORAGE_LOCAL_DIR == "" {
+		STORAGE_LOCAL_DIR = "uploads"
+	}
+	if STORAGE_BASE_URL == "" {
+		STORAGE_BASE_URL = "/files"
+	}
+	if SENDER_DRIVER == "" {
+		SENDER_DRIVER = "fake"
+	}
+
 	return &app
 }
+
+// numberSetting adalah config angka beserta batas nilai yang diizinkan. Nilai yang bukan angka
+// atau di luar batas menghentikan aplikasi agar salah ketik tidak diam-diam menjadi 0.
+type numberSetting struct {
+	name     string
+	target   *int
+	target64 *int64
+	min, max int64
+}
+
+func numberSettings() []numberSetting {
+	return []numberSetting{
+		{name: "SMTPPORT", target: &SMTP_PORT, min: 1, max: 65535},
+		{name: "OTPEXPIRY", target: &OTP_EXPIRY, min: 1, max: 1440},
+		{name: "OTPMAXATTEMPTS", target: &OTP_MAX_ATTEMPTS, min: 1, max: 100},
+		{name: "OTPRESENDINTERVAL", target: &OTP_RESEND_INTERVAL, min: 0, max: 3600},
+		{name: "OTPMAXFAILURES", target: &OTP_MAX_FAILURES, min: 1, max: 1000},
+		{name: "OTPFAILUREWINDOW", target: &OTP_FAILURE_WINDOW, min: 1, max: 10080},
+		{name: "OTPLOCKOUT", target: &OTP_LOCKOUT, min: 1, max: 10080},
+		{name: "LOGINMAXATTEMPTS", target: &LOGIN_MAX_ATTEMPTS, min: 1, max: 1000},
+		{name: "LOGINIPMAXATTEMPTS", target: &LOGIN_IP_MAX_ATTEMPTS, min: 1, max: 100000},
+		{name: "LOGINLOCKOUT", target: &LOGIN_LOCKOUT, min: 1, max: 1440},
+		{name: "LOGINLOCKOUTMAX", target: &LOGIN_LOCKOUT_MAX, min: 1, max: 10080},
+		{name: "LOGINATTEMPTWINDOW", target: &LOGIN_ATTEMPT_WINDOW, min: 1, max: 10080},
+		{name: "ACCESSTOKENEXPIRY", target: &ACCESS_TOKEN_EXPIRY, min: 1, max: 1440},
+		{name: "REFRESHTOKENEXPIRY", target: &REFRESH_TOKEN_EXPIRY, min: 1, max: 365},
+		{name: "SIGNEDURLEXPIRY", target: &SIGNED_URL_EXPIRY, min: 1, max: 10080},
+		{name: "STORAGECLEANUPINTERVAL", target: &STORAGE_CLEANUP_INTERVAL, min: 0, max: 8760},
+		{name: "UPLOADMAXSIZE", target64: &UPLOAD_MAX_SIZE, min: 1, max: 100 * 1024 * 1024},
+		{name: "IMAGEMAXDIMENSION", target: &IMAGE_MAX_DIMENSION, min: 16, max: 10000},
+		{name: "IMAGEQUALITY", target: &IMAGE_QUALITY, min: 1, max: 100},
+		{name: "IMAGETHUMBNAILSIZE", target: &IMAGE_THUMBNAIL_SIZE, min: 16, max: 2000},
+		{name: "STORAGEFREEDAYS", target: &STORAGE_FREE_DAYS, min: 0, max: 3650},
+		{name: "STORAGEFEEPERDAY", target: &STORAGE_FEE_PER_DAY, min: 0, max: 1000000000},
+	}
+}
+
+func (s numberSetting) parse(val string) {
+	value, err := strconv.ParseInt(strings.TrimSpace(val), 10, 64)
+	if err != nil || value < s.min || value > s.max {
+		log.Fatalf("config %s tidak valid: %q, harus angka antara %d dan %d", s.name, val, s.min, s.max)
+	}
+	if s.target64 != nil {
+		*s.target64 = value
+		return
+	}
+	*s.target = int(value)
+}
@@ -4,8 +4,12 @@ import (
 	"fmt"
 	"jastip-jakarta/app/config"
 	ad "jastip-jakarta/features/admin/data"
+	kd "jastip-jakarta/features/apikey/data"
+	aud "jastip-jakarta/features/audit/data"
+	authd "jastip-jakarta/features/auth/data"
 	od "jastip-jakarta/features/order/data"
 	ud "jastip-jakarta/features/user/data"
+	"log"
 
 	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
@@ -21,6 +25,14 @@ func InitDBMysql(cfg *config.AppConfig) *gorm.DB {
 		panic(err)
 	}
 
+	// kolom verifikasi ditambahkan belakangan, user yang sudah terdaftar sebelumnya
+	// dianggap terverifikasi agar tetap bisa membuat order
+	backfillVerification := DB.Migrator().HasTable(&ud.User{}) && !DB.Migrator().HasColumn(&ud.User{}, "EmailVerifiedAt")
+
+	// kolom lama penyimpanan juga ditambahkan belakangan, barang yang sudah ada di gudang
+	// harus tetap masuk laporan dan terkena biaya simpan
+	backfillStorage := DB.Migrator().HasTable(&od.OrderDetail{}) && !DB.Migrator().HasColumn(&od.OrderDetail{}, "ReceivedAt")
+
 	DB.AutoMigrate(
 		&ud.User{},
 		&od.UserOrder{},
@@ -29,7 +41,66 @@ func InitDBMysql(cfg *config.AppConfig) *gorm.DB {
 		&ad.RegionCode{},
 		&ad.DeliveryBatch{},
 		&od.PhotoOrder{},
+		&ad.Holiday{},
+		&ad.Role{},
+		&ad.RolePermission{},
+		&ad.AdminRecoveryCode{},
+		&od.ShelfLocationHistory{},
+		&od.ConditionPhoto{},
+		&od.OrderPickup{},
+		&authd.Session{},
+		&authd.OneTimeCode{},
+		&authd.LoginThrottle{},
+		&kd.APIKey{},
+		&aud.AuditLog{},
 	)
 
+	if backfillVerification {
+		err := DB.Model(&ud.User{}).Where("email_verified_at IS NULL AND phone_verified_at IS NULL").
+			Updates(map[string]interface{}{"email_verified_at": gorm.Expr("created_at"), "phone_verified_at": gorm.Expr("created_at")}).Error
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	if backfillStorage {
+		err := backfillStorageDates(DB)
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	// trigger butuh privilege TRIGGER, dan SUPER atau log_bin_trust_function_creators=1 jika
+	// binary log aktif. Tanpa itu aplikasi tetap jalan, data layer audit memang tidak punya
+	// jalur update atau delete.
+	if err := aud.EnforceAppendOnly(DB); err != nil {
+		log.Printf("PERINGATAN: trigger append-only audit_logs tidak bisa dibuat, berikan GRANT TRIGGER ON %s.* ke user database: %v", cfg.DB_NAME, err)
+	}
+
 	return DB
 }
+
+// backfillStorageDates mengisi received_at untuk barang yang sudah diterima sebelum kolom
+// tersebut ada, lalu menutup masa simpan barang yang batch-nya sudah berangkat.
+func backfillStorageDates(DB *gorm.DB) error {
+	err := DB.Model(&od.OrderDetail{}).
+		Where("received_at IS NULL AND status <> ?", "Menunggu Diterima").
+		UpdateColumn("received_at", gorm.Expr("created_at")).Error
+	if err != nil {
+		return err
+	}
+
+	err = DB.Exec(`UPDATE order_details
+		JOIN delivery_batches ON delivery_batches.id = order_details.delivery_batch_id
+		SET order_details.storage_ended_at = delivery_batches.shipped_at
+		WHERE order_details.received_at IS NOT NULL AND order_details.storage_ended_at IS NULL
+		AND delivery_batches.shipped_at IS NOT NULL`).Error
+	if err != nil {
+		return err
+	}
+
+	// batch yang dikirim sebelum ada shipped_at hanya bisa dikenali dari estimasi yang sudah diisi
+	return DB.Model(&od.OrderDetail{}).
+		Where("received_at IS NOT NULL AND storage_ended_at IS NULL AND estimated_delivery_time IS NOT NULL").
+		UpdateColumn("storage_ended_at", gorm.Expr("updated_at")).Error
+}
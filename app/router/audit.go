@@ -0,0 +1,132 @@
+package router
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"jastip-jakarta/features/admin"
+	"jastip-jakarta/features/apikey"
+	"jastip-jakarta/features/order"
+	"jastip-jakarta/features/user"
+	"jastip-jakarta/utils/middlewares"
+)
+
+// snapshot entitas untuk audit log, diambil sebelum dan sesudah admin mengubahnya
+
+func adminSnapshot(adminData admin.AdminDataInterface) middlewares.Snapshot {
+	return func(entityId string) (interface{}, error) {
+		adminId, err := strconv.Atoi(entityId)
+		if err != nil {
+			return nil, err
+		}
+		return adminData.SelectById(adminId)
+	}
+}
+
+func userSnapshot(userData user.UserDataInterface) middlewares.Snapshot {
+	return func(entityId string) (interface{}, error) {
+		userId, err := strconv.Atoi(entityId)
+		if err != nil {
+			return nil, err
+		}
+		return userData.SelectById(userId)
+	}
+}
+
+// userIdByName dipakai route yang memakai nama user, karena nama bisa ikut diubah oleh request.
+func userIdByName(userData user.UserDataInterface) middlewares.Resolver {
+	return func(name string) (string, error) {
+		result, err := userData.SelectByName(name)
+		if err != nil {
+			return "", err
+		}
+		if result == nil {
+			return "", errors.New("user tidak ditemukan")
+		}
+		return strconv.Itoa(int(result.ID)), nil
+	}
+}
+
+func roleSnapshot(adminData admin.AdminDataInterface) middlewares.Snapshot {
+	return func(entityId string) (interface{}, error) {
+		return adminData.SelectRoleByName(entityId)
+	}
+}
+
+func batchSnapshot(adminData admin.AdminDataInterface) middlewares.Snapshot {
+	return func(entityId string) (interface{}, error) {
+		return adminData.SelectDeliveryBatch(entityId)
+	}
+}
+
+func holidaySnapshot(adminData admin.AdminDataInterface) middlewares.Snapshot {
+	return func(entityId string) (interface{}, error) {
+		holidays, err := adminData.SelectAllHoliday()
+		if err != nil {
+			return nil, err
+		}
+		for _, holiday := range holidays {
+			if strconv.Itoa(int(holiday.ID)) == entityId {
+				return holiday, nil
+			}
+		}
+		return nil, errors.New("hari libur tidak ditemukan")
+	}
+}
+
+func regionSnapshot(adminData admin.AdminDataInterface) middlewares.Snapshot {
+	return func(entityId string) (interface{}, error) {
+		return adminData.SelectByIdRegion(entityId)
+	}
+}
+
+func orderSnapshot(orderData order.OrderDataInterface) middlewares.Snapshot {
+	return func(entityId string) (interface{}, error) {
+		orderId, err := strconv.Atoi(entityId)
+		if err != nil {
+			return nil, err
+		}
+		return orderData.SelectById(uint(orderId))
+	}
+}
+
+// batchOrdersSnapshot mengambil semua order satu batch dan region dengan ID "batch:code",
+// dikelompokkan per ID order agar perubahannya tercatat per order.
+func batchOrdersSnapshot(orderData order.OrderDataInterface) middlewares.Snapshot {
+	return func(entityId string) (interface{}, error) {
+		batch, code, found := strings.Cut(entityId, ":")
+		if !found || batch == "" || code == "" {
+			return nil, errors.New("batch dan region tidak valid")
+		}
+		orders, err := orderData.SelectNameByUserOrder(code, batch)
+		if err != nil {
+			return nil, err
+		}
+		result := make(map[string]order.UserOrder, len(orders))
+		for _, userOrder := range orders {
+			result[strconv.Itoa(int(userOrder.ID))] = userOrder
+		}
+		return result, nil
+	}
+}
+
+func photoOrderSnapshot(orderData order.OrderDataInterface) middlewares.Snapshot {
+	return func(entityId string) (interface{}, error) {
+		photoId, err := strconv.Atoi(entityId)
+		if err != nil {
+			return nil, err
+		}
+		return orderData.SelectPhotoOrderById(uint(photoId))
+	}
+}
+
+func apiKeySnapshot(apiKeyData apikey.APIKeyDataInterface) middlewares.Snapshot {
+	return func(entityId string) (interface{}, error) {
+		keyId, err := strconv.Atoi(entityId)
+		if err != nil {
+			return nil, err
+		}
+		return apiKeyData.SelectById(uint(keyId))
+	}
+}
@@ -0,0 +1,34 @@
+package router
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"jastip-jakarta/app/config"
+
+	"github.com/labstack/echo/v4"
+)
+
+// IPExtractor menentukan IP klien untuk pembatasan login dan audit log. Header
+// X-Forwarded-For hanya dipercaya jika request datang dari proxy di config.TRUSTED_PROXIES,
+// selain itu header bisa dipalsukan klien untuk menghindari batas per IP.
+func IPExtractor() (echo.IPExtractor, error) {
+	if strings.TrimSpace(config.TRUSTED_PROXIES) == "" {
+		return echo.ExtractIPDirect(), nil
+	}
+
+	options := []echo.TrustOption{
+		echo.TrustLoopback(false),
+		echo.TrustLinkLocal(false),
+		echo.TrustPrivateNet(false),
+	}
+	for _, cidr := range strings.Split(config.TRUSTED_PROXIES, ",") {
+		_, ipNet, err := net.ParseCIDR(strings.TrimSpace(cidr))
+		if err != nil {
+			return nil, fmt.Errorf("CIDR proxy tidak valid %q: %w", cidr, err)
+		}
+		options = append(options, echo.TrustIPRange(ipNet))
+	}
+	return echo.ExtractIPFromXFFHeader(options...), nil
+}
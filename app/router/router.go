@@ -1,15 +1,38 @@
 package router
 
 import (
-	"jastip-jakarta/utils/cloudinary"
+	"log"
+	"net/http"
+	"time"
+
+	"jastip-jakarta/app/config"
 	"jastip-jakarta/utils/csv"
 	"jastip-jakarta/utils/encrypts"
+	"jastip-jakarta/utils/label"
+	"jastip-jakarta/utils/manifest"
 	"jastip-jakarta/utils/middlewares"
+	"jastip-jakarta/utils/sender"
+	"jastip-jakarta/utils/storage"
+
+	"jastip-jakarta/features/apikey"
+	kd "jastip-jakarta/features/apikey/data"
+	kh "jastip-jakarta/features/apikey/handler"
+	ks "jastip-jakarta/features/apikey/service"
+
+	"jastip-jakarta/features/audit"
+	aud "jastip-jakarta/features/audit/data"
+	auh "jastip-jakarta/features/audit/handler"
+	aus "jastip-jakarta/features/audit/service"
+
+	authd "jastip-jakarta/features/auth/data"
+	authh "jastip-jakarta/features/auth/handler"
+	auths "jastip-jakarta/features/auth/service"
 
 	ud "jastip-jakarta/features/user/data"
 	uh "jastip-jakarta/features/user/handler"
 	us "jastip-jakarta/features/user/service"
 
+	"jastip-jakarta/features/admin"
 	ad "jastip-jakarta/features/admin/data"
 	ah "jastip-jakarta/features/admin/handler"
 	as "jastip-jakarta/features/admin/service"
@@ -24,77 +47,184 @@ import (
 
 func InitRouter(db *gorm.DB, e *echo.Echo) {
 	hash := encrypts.New()
-	cloudinaryUploader := cloudinary.New()
+	objectStorage, err := storage.New()
+	if err != nil {
+		log.Fatal("error init storage : ", err.Error())
+	}
+	uploader := storage.NewUploader(objectStorage)
 	csvGenerator := csv.New()
+	manifestGenerator := manifest.New()
+	labelGenerator := label.New()
+	messageSender, err := sender.New()
+	if err != nil {
+		log.Fatal("error init sender : ", err.Error())
+	}
 
-	userData := ud.New(db, cloudinaryUploader)
-	userService := us.New(userData, hash)
+	authData := authd.New(db)
+	authService := auths.New(authData)
+	authHandlerAPI := authh.New(authService)
+	middlewares.SetSessionChecker(authService)
+
+	userData := ud.New(db, uploader)
+	userService := us.New(userData, hash, authService, messageSender)
 	userHandlerAPI := uh.New(userService)
 
-	adminData := ad.New(db, cloudinaryUploader)
-	adminService := as.New(adminData, hash, userData)
+	adminData := ad.New(db, uploader)
+	adminService := as.New(adminData, hash, userData, objectStorage, authService, messageSender)
 	adminHandlerAPI := ah.New(adminService)
+	if err := adminService.SeedDefaultRoles(); err != nil {
+		log.Fatal("error seed roles : ", err.Error())
+	}
+	authorizer := middlewares.NewAuthorizer(adminService)
+
+	auditData := aud.New(db)
+	auditService := aus.New(auditData)
+	auditHandlerAPI := auh.New(auditService)
+	auditor := middlewares.NewAuditor(auditService)
 
-	orderData := od.New(db, cloudinaryUploader, csvGenerator)
-	orderService := os.New(orderData, adminService)
+	apiKeyData := kd.New(db)
+	apiKeyService := ks.New(apiKeyData, adminData, userData)
+	apiKeyHandlerAPI := kh.New(apiKeyService)
+	middlewares.SetAPIKeyChecker(apiKeyService)
+
+	orderData := od.New(db, uploader, csvGenerator)
+	orderService := os.New(orderData, adminService, userService, manifestGenerator, labelGenerator, uploader)
 	orderHandlerAPI := oh.New(orderService)
 
+	if config.STORAGE_CLEANUP_INTERVAL > 0 {
+		adminService.StartOrphanCleanupJob(time.Duration(config.STORAGE_CLEANUP_INTERVAL) * time.Hour)
+	}
+
+	// file dari driver penyimpanan lokal disajikan oleh aplikasi sendiri
+	if localStorage, ok := objectStorage.(*storage.LocalStorage); ok {
+		e.GET("/files/*", echo.WrapHandler(http.StripPrefix("/files", localStorage)))
+	}
+
 	// define routes/ endpoint USERS
 	e.POST("users/login", userHandlerAPI.Login)
 	e.POST("users/register", userHandlerAPI.RegisterUser)
-	e.GET("/users/profile", userHandlerAPI.GetUser, middlewares.JWTMiddleware())
-	e.PUT("/users/profile", userHandlerAPI.UpdateUser, middlewares.JWTMiddleware())
+	e.POST("/users/token/refresh", authHandlerAPI.RefreshUser)
+	e.POST("/users/password/forgot", userHandlerAPI.ForgotPassword)
+	e.POST("/users/password/reset", userHandlerAPI.ResetPassword)
+	e.PUT("/users/password", userHandlerAPI.ChangePassword, middlewares.UserJWTMiddleware())
+	e.POST("/users/logout", authHandlerAPI.Logout, middlewares.UserJWTMiddleware())
+	e.POST("/users/logout/all", authHandlerAPI.LogoutAllUser, middlewares.UserJWTMiddleware())
+	e.GET("/users/profile", userHandlerAPI.GetUser, middlewares.UserJWTMiddleware())
+	e.PUT("/users/profile", userHandlerAPI.UpdateUser, middlewares.UserJWTMiddleware())
+	e.DELETE("/users/profile/photo", userHandlerAPI.DeletePhoto, middlewares.UserJWTMiddleware())
+	e.POST("/users/verify/send", userHandlerAPI.SendVerificationCode, middlewares.UserJWTMiddleware())
+	e.POST("/users/verify", userHandlerAPI.Verify, middlewares.UserJWTMiddleware())
 
 	// define routes/ endpoint ADMIN
-	e.POST("/admin/register", adminHandlerAPI.RegisterAdminSuper)
+	e.POST("/admin/setup", adminHandlerAPI.SetupAdminSuper, auditor.Track(audit.EntityAdmin, "", adminSnapshot(adminData)))
 	e.POST("/admin/login", adminHandlerAPI.Login)
-	e.POST("/admin/new", adminHandlerAPI.RegisterAdmin, middlewares.JWTMiddleware())
-	e.GET("/admin/profile", adminHandlerAPI.GetAdmin, middlewares.JWTMiddleware())
-	e.PUT("/admin/profile", adminHandlerAPI.UpdateAdmin, middlewares.JWTMiddleware())
-	e.GET("/admin/perwakilan", adminHandlerAPI.GetAdminPerwakilan, middlewares.JWTMiddleware())
-	e.GET("/admin/jakarta", adminHandlerAPI.GetAdminJakarta, middlewares.JWTMiddleware())
-	e.GET("/admin/all", adminHandlerAPI.GetAllAdmin, middlewares.JWTMiddleware())
-	e.GET("/admin/user/search", adminHandlerAPI.SearchUser, middlewares.JWTMiddleware())
-	e.PUT("/admin/user/:name", adminHandlerAPI.UpdateUserByName, middlewares.JWTMiddleware())
-	e.POST("/admin/user", adminHandlerAPI.CreateUser, middlewares.JWTMiddleware())
-	e.GET("/admin/user", adminHandlerAPI.GetAllUSer, middlewares.JWTMiddleware())
-	
+	e.POST("/admin/token/refresh", authHandlerAPI.RefreshAdmin)
+	e.POST("/admin/password/forgot", adminHandlerAPI.ForgotPassword)
+	e.POST("/admin/password/reset", adminHandlerAPI.ResetPassword)
+	e.PUT("/admin/password", adminHandlerAPI.ChangePassword, middlewares.AdminJWTMiddleware(), auditor.TrackSelf(audit.EntityAdmin, adminSnapshot(adminData)))
+	e.POST("/admin/logout", authHandlerAPI.Logout, middlewares.AdminJWTMiddleware())
+	e.POST("/admin/logout/all", authHandlerAPI.LogoutAllAdmin, middlewares.AdminJWTMiddleware())
+	e.GET("/admin/login/locked", authHandlerAPI.GetLockedLogins, middlewares.AdminJWTMiddleware(), authorizer.Require(admin.PermissionLoginManage))
+	e.DELETE("/admin/login/locked/:id", authHandlerAPI.UnlockLogin, middlewares.AdminJWTMiddleware(), authorizer.Require(admin.PermissionLoginManage), auditor.Track(audit.EntityLoginThrottle, "id", nil))
+	e.POST("/admin/apikey", apiKeyHandlerAPI.CreateAPIKey, middlewares.AdminJWTMiddleware(), authorizer.Require(admin.PermissionAPIKey), auditor.Track(audit.EntityAPIKey, "", apiKeySnapshot(apiKeyData)))
+	e.GET("/admin/apikey", apiKeyHandlerAPI.GetAllAPIKey, middlewares.AdminJWTMiddleware(), authorizer.Require(admin.PermissionAPIKey))
+	e.DELETE("/admin/apikey/:id", apiKeyHandlerAPI.RevokeAPIKey, middlewares.AdminJWTMiddleware(), authorizer.Require(admin.PermissionAPIKey), auditor.Track(audit.EntityAPIKey, "id", apiKeySnapshot(apiKeyData)))
+	e.GET("/admin/audit", auditHandlerAPI.SearchAuditLog, middlewares.AdminJWTMiddleware(), authorizer.Require(admin.PermissionAuditRead))
+	e.GET("/admin/audit/csv", auditHandlerAPI.ExportAuditLog, middlewares.AdminJWTMiddleware(), authorizer.Require(admin.PermissionAuditRead))
+	e.POST("/admin/new", adminHandlerAPI.RegisterAdmin, middlewares.AdminJWTMiddleware(), authorizer.Require(admin.PermissionAdminManage), auditor.Track(audit.EntityAdmin, "", adminSnapshot(adminData)))
+	e.GET("/admin/profile", adminHandlerAPI.GetAdmin, middlewares.AdminJWTMiddleware())
+	e.PUT("/admin/profile", adminHandlerAPI.UpdateAdmin, middlewares.AdminJWTMiddleware(), auditor.TrackSelf(audit.EntityAdmin, adminSnapshot(adminData)))
+	e.DELETE("/admin/profile/photo", adminHandlerAPI.DeletePhoto, middlewares.AdminJWTMiddleware(), auditor.TrackSelf(audit.EntityAdmin, adminSnapshot(adminData)))
+	e.POST("/admin/2fa/enroll", adminHandlerAPI.EnrollTwoFactor, middlewares.AdminJWTMiddleware(), auditor.TrackSelf(audit.EntityAdmin, adminSnapshot(adminData)))
+	e.POST("/admin/2fa/confirm", adminHandlerAPI.ConfirmTwoFactor, middlewares.AdminJWTMiddleware(), auditor.TrackSelf(audit.EntityAdmin, adminSnapshot(adminData)))
+	e.POST("/admin/2fa/recovery-codes", adminHandlerAPI.RegenerateRecoveryCodes, middlewares.AdminJWTMiddleware(), auditor.TrackSelf(audit.EntityAdmin, adminSnapshot(adminData)))
+	e.DELETE("/admin/2fa", adminHandlerAPI.DisableTwoFactor, middlewares.AdminJWTMiddleware(), auditor.TrackSelf(audit.EntityAdmin, adminSnapshot(adminData)))
+	e.DELETE("/admin/2fa/:admin_id", adminHandlerAPI.ResetTwoFactor, middlewares.AdminJWTMiddleware(), authorizer.Require(admin.PermissionAdminManage), auditor.Track(audit.EntityAdmin, "admin_id", adminSnapshot(adminData)))
+	e.POST("/admin/storage/cleanup", adminHandlerAPI.CleanupOrphanPhotos, middlewares.AdminJWTMiddleware(), authorizer.Require(admin.PermissionStorage), auditor.Track(audit.EntityStorage, "", nil))
+	e.GET("/admin/perwakilan", adminHandlerAPI.GetAdminPerwakilan, middlewares.AdminJWTMiddleware(), authorizer.Require(admin.PermissionAdminManage))
+	e.GET("/admin/jakarta", adminHandlerAPI.GetAdminJakarta, middlewares.AdminJWTMiddleware(), authorizer.Require(admin.PermissionAdminManage))
+	e.GET("/admin/all", adminHandlerAPI.GetAllAdmin, middlewares.AdminJWTMiddleware(), authorizer.Require(admin.PermissionAdminManage))
+	e.PUT("/admin/account/:admin_id/deactivate", adminHandlerAPI.DeactivateAdmin, middlewares.AdminJWTMiddleware(), authorizer.Require(admin.PermissionAdminManage), auditor.Track(audit.EntityAdmin, "admin_id", adminSnapshot(adminData)))
+	e.PUT("/admin/account/:admin_id/activate", adminHandlerAPI.ActivateAdmin, middlewares.AdminJWTMiddleware(), authorizer.Require(admin.PermissionAdminManage), auditor.Track(audit.EntityAdmin, "admin_id", adminSnapshot(adminData)))
+	e.PUT("/admin/account/:admin_id/role", adminHandlerAPI.ChangeAdminRole, middlewares.AdminJWTMiddleware(), authorizer.Require(admin.PermissionAdminManage), auditor.Track(audit.EntityAdmin, "admin_id", adminSnapshot(adminData)))
+	e.POST("/admin/account/:admin_id/password/reset", adminHandlerAPI.ForcePasswordReset, middlewares.AdminJWTMiddleware(), authorizer.Require(admin.PermissionAdminManage), auditor.Track(audit.EntityAdmin, "admin_id", adminSnapshot(adminData)))
+	e.DELETE("/admin/account/:admin_id", adminHandlerAPI.DeleteAdmin, middlewares.AdminJWTMiddleware(), authorizer.Require(admin.PermissionAdminManage), auditor.Track(audit.EntityAdmin, "admin_id", adminSnapshot(adminData)))
+	e.GET("/admin/user/search", adminHandlerAPI.SearchUser, middlewares.AdminJWTMiddleware(), authorizer.Require(admin.PermissionUserManage))
+	e.PUT("/admin/user/:name", adminHandlerAPI.UpdateUserByName, middlewares.AdminJWTMiddleware(), authorizer.Require(admin.PermissionUserManage), auditor.TrackResolved(audit.EntityUser, "name", userIdByName(userData), userSnapshot(userData)))
+	e.PUT("/admin/user/:name/verification", adminHandlerAPI.UpdateUserVerification, middlewares.AdminJWTMiddleware(), authorizer.Require(admin.PermissionUserManage), auditor.TrackResolved(audit.EntityUser, "name", userIdByName(userData), userSnapshot(userData)))
+	e.POST("/admin/user", adminHandlerAPI.CreateUser, middlewares.AdminJWTMiddleware(), authorizer.Require(admin.PermissionUserManage), auditor.Track(audit.EntityUser, "", userSnapshot(userData)))
+	e.GET("/admin/user", adminHandlerAPI.GetAllUSer, middlewares.AdminJWTMiddleware(), authorizer.Require(admin.PermissionUserManage))
+
+	// define routes/ endpoint ROLE
+	e.POST("/admin/role", adminHandlerAPI.CreateRole, middlewares.AdminJWTMiddleware(), authorizer.Require(admin.PermissionRoleManage), auditor.Track(audit.EntityRole, "", roleSnapshot(adminData)))
+	e.GET("/admin/role", adminHandlerAPI.GetAllRoles, middlewares.AdminJWTMiddleware(), authorizer.Require(admin.PermissionRoleManage))
+	e.PUT("/admin/role/:name", adminHandlerAPI.UpdateRole, middlewares.AdminJWTMiddleware(), authorizer.Require(admin.PermissionRoleManage), auditor.Track(audit.EntityRole, "name", roleSnapshot(adminData)))
+	e.DELETE("/admin/role/:name", adminHandlerAPI.DeleteRole, middlewares.AdminJWTMiddleware(), authorizer.Require(admin.PermissionRoleManage), auditor.Track(audit.EntityRole, "name", roleSnapshot(adminData)))
 	// define routes/ endpoint BATCH
-	e.POST("/admin/batch", adminHandlerAPI.CreateDeliveryBatch, middlewares.JWTMiddleware())
+	e.POST("/admin/batch", adminHandlerAPI.CreateDeliveryBatch, middlewares.AdminJWTMiddleware(), authorizer.Require(admin.PermissionBatchManage), auditor.Track(audit.EntityBatch, "", batchSnapshot(adminData)))
 	e.GET("/batch", adminHandlerAPI.GetAllDeliveryBatch)
 	e.GET("/batch/:batch_id", adminHandlerAPI.GetDeliveryBatchById)
+	e.PUT("/admin/batch/:batch_id/shipped", orderHandlerAPI.ShipDeliveryBatch, middlewares.AdminJWTMiddleware(), authorizer.Require(admin.PermissionBatchManage), auditor.Track(audit.EntityBatch, "batch_id", batchSnapshot(adminData)))
+
+	// define routes/ endpoint HOLIDAY
+	e.POST("/admin/holiday", adminHandlerAPI.CreateHoliday, middlewares.AdminJWTMiddleware(), authorizer.Require(admin.PermissionHoliday), auditor.Track(audit.EntityHoliday, "", holidaySnapshot(adminData)))
+	e.GET("/holiday", adminHandlerAPI.GetAllHoliday)
+	e.DELETE("/admin/holiday/:holiday_id", adminHandlerAPI.DeleteHoliday, middlewares.AdminJWTMiddleware(), authorizer.Require(admin.PermissionHoliday), auditor.Track(audit.EntityHoliday, "holiday_id", holidaySnapshot(adminData)))
 	
 	// define routes/ endpoint REGION
-	e.POST("/admin/region", adminHandlerAPI.CreateRegionCode, middlewares.JWTMiddleware())
+	e.POST("/admin/region", adminHandlerAPI.CreateRegionCode, middlewares.AdminJWTMiddleware(), authorizer.Require(admin.PermissionRegionManage), auditor.Track(audit.EntityRegion, "", regionSnapshot(adminData)))
 	e.GET("/region", adminHandlerAPI.GetRegionCode)
 	e.GET("/region/:code", adminHandlerAPI.GetRegionCodeById)
-	e.GET("/admin/region/search", adminHandlerAPI.SearchRegionCode, middlewares.JWTMiddleware())
-	e.PUT("/admin/region/:code", adminHandlerAPI.UpdateRegionCode, middlewares.JWTMiddleware())
+	e.GET("/admin/region/search", adminHandlerAPI.SearchRegionCode, middlewares.AdminJWTMiddleware(), authorizer.Require(admin.PermissionRegionManage))
+	e.PUT("/admin/region/:code", adminHandlerAPI.UpdateRegionCode, middlewares.AdminJWTMiddleware(), authorizer.Require(admin.PermissionRegionManage), auditor.Track(audit.EntityRegion, "code", regionSnapshot(adminData)))
 
 	// define routes/ endpoint USER ORDER
-	e.POST("/users/order", orderHandlerAPI.CreateUserOrder, middlewares.JWTMiddleware())
-	e.PUT("/users/order/:order_id", orderHandlerAPI.UpdateUserOrder, middlewares.JWTMiddleware())
-	e.GET("/users/order/wait", orderHandlerAPI.GetUserOrderWait, middlewares.JWTMiddleware())
-	e.GET("/users/order/:order_id", orderHandlerAPI.GetOrderById)
-	e.GET("/users/order/process", orderHandlerAPI.GetUserOrderProcess, middlewares.JWTMiddleware())
-	e.GET("/users/order/search", orderHandlerAPI.SearchUserOrder, middlewares.JWTMiddleware())
+	e.POST("/users/order", orderHandlerAPI.CreateUserOrder, middlewares.UserJWTOrAPIKey(apikey.ScopeOrdersCreate))
+	e.PUT("/users/order/:order_id", orderHandlerAPI.UpdateUserOrder, middlewares.UserJWTMiddleware())
+	e.GET("/users/order/wait", orderHandlerAPI.GetUserOrderWait, middlewares.UserJWTOrAPIKey(apikey.ScopeOrdersRead))
+	e.GET("/users/order/:order_id", orderHandlerAPI.GetOrderById, middlewares.UserJWTOrAPIKey(apikey.ScopeOrdersRead))
+	e.GET("/users/order/process", orderHandlerAPI.GetUserOrderProcess, middlewares.UserJWTOrAPIKey(apikey.ScopeOrdersRead))
+	e.GET("/users/order/search", orderHandlerAPI.SearchUserOrder, middlewares.UserJWTOrAPIKey(apikey.ScopeOrdersRead))
 
 	// define routes/ endpoint ADMIN ORDER
-	e.POST("/admin/order/:order_id", orderHandlerAPI.CreateOrderDetail, middlewares.JWTMiddleware())
-	e.GET("/admin/order", orderHandlerAPI.GetAllUserOrderWait, middlewares.JWTMiddleware())
-	e.GET("/admin/order/batch", orderHandlerAPI.GetDeliveryBatchWithRegion, middlewares.JWTMiddleware())
-	e.GET("/admin/order/name", orderHandlerAPI.GetUserOrderNames, middlewares.JWTMiddleware())
-	e.GET("/admin/order/name/orders", orderHandlerAPI.GetOrderByNameUser, middlewares.JWTMiddleware())
-	e.POST("/admin/order/estimasi", orderHandlerAPI.UpdateEstimationForOrders, middlewares.JWTMiddleware())
-	e.PUT("/admin/order/status/:order_id", orderHandlerAPI.UpdateOrderStatus, middlewares.JWTMiddleware())
-	e.GET("/admin/order/search", orderHandlerAPI.SearchOrder, middlewares.JWTMiddleware())
-	e.PUT("/admin/order/:order_id", orderHandlerAPI.UpdateOrderById, middlewares.JWTMiddleware())
-	e.GET("/admin/order/statistik/:batch", orderHandlerAPI.GetOrderSStats, middlewares.JWTMiddleware())
+	e.GET("/admin/order/:order_id", orderHandlerAPI.GetOrderByIdAdmin, middlewares.AdminJWTOrAPIKey(apikey.ScopeOrdersRead), authorizer.Require(admin.PermissionOrderRead))
+	e.POST("/admin/order/:order_id", orderHandlerAPI.CreateOrderDetail, middlewares.AdminJWTMiddleware(), authorizer.Require(admin.PermissionOrderReceive), auditor.Track(audit.EntityOrder, "order_id", orderSnapshot(orderData)))
+	e.POST("/admin/order/:order_id/foto", orderHandlerAPI.UploadConditionPhotos, middlewares.AdminJWTMiddleware(), authorizer.Require(admin.PermissionOrderReceive), auditor.Track(audit.EntityOrder, "order_id", orderSnapshot(orderData)))
+	e.GET("/admin/order/:order_id/foto", orderHandlerAPI.GetConditionPhotos, middlewares.AdminJWTMiddleware(), authorizer.Require(admin.PermissionPhotoRead))
+	e.POST("/admin/order/:order_id/pickup", orderHandlerAPI.RecordPickup, middlewares.AdminJWTMiddleware(), authorizer.Require(admin.PermissionOrderDeliver), auditor.Track(audit.EntityOrder, "order_id", orderSnapshot(orderData)))
+	e.DELETE("/admin/order/:order_id/foto/:photo_id", orderHandlerAPI.DeleteConditionPhoto, middlewares.AdminJWTMiddleware(), authorizer.Require(admin.PermissionOrderReceive), auditor.Track(audit.EntityOrder, "order_id", orderSnapshot(orderData)))
+	e.GET("/admin/order", orderHandlerAPI.GetAllUserOrderWait, middlewares.AdminJWTOrAPIKey(apikey.ScopeOrdersRead), authorizer.Require(admin.PermissionOrderRead))
+	e.GET("/admin/order/batch", orderHandlerAPI.GetDeliveryBatchWithRegion, middlewares.AdminJWTOrAPIKey(apikey.ScopeOrdersRead), authorizer.Require(admin.PermissionOrderRead))
+	e.GET("/admin/order/name", orderHandlerAPI.GetUserOrderNames, middlewares.AdminJWTMiddleware(), authorizer.Require(admin.PermissionOrderRead))
+	e.GET("/admin/order/name/orders", orderHandlerAPI.GetOrderByNameUser, middlewares.AdminJWTMiddleware(), authorizer.Require(admin.PermissionOrderRead))
+	e.POST("/admin/order/estimasi", orderHandlerAPI.UpdateEstimationForOrders, middlewares.AdminJWTMiddleware(), authorizer.Require(admin.PermissionEstimate), auditor.TrackQuery(audit.EntityBatchOrders, batchOrdersSnapshot(orderData), "batch", "code"))
+	e.PUT("/admin/order/status/:order_id", orderHandlerAPI.UpdateOrderStatus, middlewares.AdminJWTMiddleware(), authorizer.Require(admin.PermissionOrderDeliver), auditor.Track(audit.EntityOrder, "order_id", orderSnapshot(orderData)))
+	e.GET("/admin/order/search", orderHandlerAPI.SearchOrder, middlewares.AdminJWTOrAPIKey(apikey.ScopeOrdersRead), authorizer.Require(admin.PermissionOrderRead))
+	e.PUT("/admin/order/:order_id", orderHandlerAPI.UpdateOrderById, middlewares.AdminJWTMiddleware(), authorizer.Require(admin.PermissionOrderUpdate), auditor.Track(audit.EntityOrder, "order_id", orderSnapshot(orderData)))
+	e.GET("/admin/order/statistik/:batch", orderHandlerAPI.GetOrderSStats, middlewares.AdminJWTMiddleware(), authorizer.Require(admin.PermissionOrderStats))
+
+	// define routes/ endpoint ADMIN GUDANG
+	e.GET("/admin/gudang/lokasi", orderHandlerAPI.FindOrderLocation, middlewares.AdminJWTMiddleware(), authorizer.Require(admin.PermissionWarehouse))
+	e.PUT("/admin/gudang/lokasi/:order_id", orderHandlerAPI.MoveShelfLocation, middlewares.AdminJWTMiddleware(), authorizer.Require(admin.PermissionWarehouse), auditor.Track(audit.EntityOrder, "order_id", orderSnapshot(orderData)))
+	e.GET("/admin/gudang/lokasi/:order_id/riwayat", orderHandlerAPI.GetShelfLocationHistory, middlewares.AdminJWTMiddleware(), authorizer.Require(admin.PermissionWarehouse))
+	e.GET("/admin/gudang/picklist", orderHandlerAPI.GetPickList, middlewares.AdminJWTMiddleware(), authorizer.Require(admin.PermissionWarehouse))
+	e.GET("/admin/gudang/aging", orderHandlerAPI.GetStorageAging, middlewares.AdminJWTMiddleware(), authorizer.Require(admin.PermissionWarehouse))
 
 	// define routes/ endpoint ADMIN FOTO
-	e.POST("/admin/foto", orderHandlerAPI.UploadFotoPacked, middlewares.JWTMiddleware())
-	e.PUT("/admin/foto/:id_foto", orderHandlerAPI.UploadFotoReceived, middlewares.JWTMiddleware())
+	e.POST("/admin/foto", orderHandlerAPI.UploadFotoPacked, middlewares.AdminJWTMiddleware(), authorizer.Require(admin.PermissionOrderReceive), auditor.Track(audit.EntityPhotoOrder, "", photoOrderSnapshot(orderData)))
+	e.PUT("/admin/foto/:id_foto", orderHandlerAPI.UploadFotoReceived, middlewares.AdminJWTMiddleware(), authorizer.Require(admin.PermissionOrderDeliver), auditor.Track(audit.EntityPhotoOrder, "id_foto", photoOrderSnapshot(orderData)))
+	e.PUT("/admin/foto/:id_foto/packed", orderHandlerAPI.ReplaceFotoPacked, middlewares.AdminJWTMiddleware(), authorizer.Require(admin.PermissionOrderReceive), auditor.Track(audit.EntityPhotoOrder, "id_foto", photoOrderSnapshot(orderData)))
+	e.DELETE("/admin/foto/:id_foto", orderHandlerAPI.DeletePhotoOrder, middlewares.AdminJWTMiddleware(), authorizer.Require(admin.PermissionOrderReceive), auditor.Track(audit.EntityPhotoOrder, "id_foto", photoOrderSnapshot(orderData)))
 
 	// define routes/ endpoint ADMIN CSV
-	e.GET("/download/csv", orderHandlerAPI.GenerateCSVByBatch)
+	e.GET("/download/csv", orderHandlerAPI.GenerateCSVByBatch, middlewares.AdminJWTOrAPIKey(apikey.ScopeExport), authorizer.Require(admin.PermissionDocument))
+
+	// define routes/ endpoint ADMIN MANIFEST
+	e.GET("/admin/manifest", orderHandlerAPI.GenerateManifestByBatch, middlewares.AdminJWTOrAPIKey(apikey.ScopeExport), authorizer.Require(admin.PermissionDocument))
+
+	// define routes/ endpoint ADMIN LABEL
+	e.GET("/admin/label/order/:order_id", orderHandlerAPI.GenerateOrderLabel, middlewares.AdminJWTMiddleware(), authorizer.Require(admin.PermissionDocument))
+	e.GET("/admin/label/box", orderHandlerAPI.GenerateBoxLabel, middlewares.AdminJWTMiddleware(), authorizer.Require(admin.PermissionDocument))
+	e.GET("/admin/label/batch", orderHandlerAPI.GenerateBatchLabels, middlewares.AdminJWTMiddleware(), authorizer.Require(admin.PermissionDocument))
+	e.GET("/admin/scan", orderHandlerAPI.ScanLabel, middlewares.AdminJWTMiddleware(), authorizer.Require(admin.PermissionOrderRead))
 }
\ No newline at end of file
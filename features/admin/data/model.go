@@ -2,6 +2,7 @@ package data
 
 import (
 	"jastip-jakarta/features/admin"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -16,6 +17,21 @@ type Admin struct {
 	PhotoProfile string
 	Role         string
 	RegionCode   RegionCode `gorm:"foreignKey:AdminID"`
+
+	TwoFactorSecret  string `gorm:"type:varchar(64)"`
+	TwoFactorEnabled bool
+	// time step TOTP terakhir yang diterima, kode dari step yang sama atau lebih lama ditolak
+	TwoFactorLastStep int64
+	DeactivatedAt     *time.Time
+}
+
+// AdminRecoveryCode menyimpan hash recovery code 2FA, setiap kode hanya bisa dipakai sekali.
+type AdminRecoveryCode struct {
+	ID        uint   `gorm:"primaryKey"`
+	AdminID   uint   `gorm:"index"`
+	CodeHash  string `gorm:"type:varchar(64)"`
+	UsedAt    *time.Time
+	CreatedAt time.Time
 }
 
 type RegionCode struct {
@@ -25,17 +41,38 @@ type RegionCode struct {
 	FullAddress string
 	PhoneNumber int
 	Price       int
+	MinLeadDays int
+	MaxLeadDays int
 	AdminID     uint
 }
 
 type DeliveryBatch struct {
 	ID string `gorm:"type:varchar(255);primaryKey" json:"id"`
 	gorm.Model
-	Batch   int
-	Year    int
-	Month   int
-	AdminID uint
-	Admin   Admin `gorm:"foreignKey:AdminID"`
+	Batch     int
+	Year      int
+	Month     int
+	AdminID   uint
+	ShippedAt *time.Time
+	Admin     Admin `gorm:"foreignKey:AdminID"`
+}
+
+type Role struct {
+	gorm.Model
+	Name        string           `gorm:"type:varchar(100);uniqueIndex"`
+	Permissions []RolePermission `gorm:"foreignKey:RoleID"`
+}
+
+type RolePermission struct {
+	ID         uint   `gorm:"primaryKey"`
+	RoleID     uint   `gorm:"index"`
+	Permission string `gorm:"type:varchar(100)"`
+}
+
+type Holiday struct {
+	gorm.Model
+	Date time.Time `gorm:"type:date;uniqueIndex"`
+	Name string
 }
 
 func AdminToModel(input admin.Admin) Admin {
@@ -61,6 +98,10 @@ func (u Admin) ModelToAdmin() admin.Admin {
 		Role:         u.Role,
 		CreatedAt:    u.CreatedAt,
 		UpdatedAt:    u.UpdatedAt,
+
+		TwoFactorSecret:  u.TwoFactorSecret,
+		TwoFactorEnabled: u.TwoFactorEnabled,
+		DeactivatedAt:    u.DeactivatedAt,
 	}
 }
 
@@ -71,6 +112,8 @@ func RegionCodeToModel(input admin.RegionCode) RegionCode {
 		FullAddress: input.FullAddress,
 		PhoneNumber: input.PhoneNumber,
 		Price:       input.Price,
+		MinLeadDays: input.MinLeadDays,
+		MaxLeadDays: input.MaxLeadDays,
 		AdminID:     input.AdminID,
 	}
 }
@@ -82,6 +125,8 @@ func (u RegionCode) ModelToRegionCode() admin.RegionCode {
 		FullAddress: u.FullAddress,
 		PhoneNumber: u.PhoneNumber,
 		Price:       u.Price,
+		MinLeadDays: u.MinLeadDays,
+		MaxLeadDays: u.MaxLeadDays,
 		AdminID:     u.AdminID,
 		CreatedAt:   u.CreatedAt,
 		UpdatedAt:   u.UpdatedAt,
@@ -99,9 +144,52 @@ func DeliveryBatchToModel(input admin.DeliveryBatch) DeliveryBatch {
 
 func (u DeliveryBatch) ModelToDeliveryBatch() admin.DeliveryBatch {
 	return admin.DeliveryBatch{
-		ID:    u.ID,
-		Batch: u.Batch,
-		Year:  u.Year,
-		Month: u.Month,
+		ID:        u.ID,
+		Batch:     u.Batch,
+		Year:      u.Year,
+		Month:     u.Month,
+		ShippedAt: u.ShippedAt,
+	}
+}
+
+func HolidayToModel(input admin.Holiday) Holiday {
+	return Holiday{
+		Date: input.Date,
+		Name: input.Name,
+	}
+}
+
+func (h Holiday) ModelToHoliday() admin.Holiday {
+	return admin.Holiday{
+		ID:        h.ID,
+		Date:      h.Date,
+		Name:      h.Name,
+		CreatedAt: h.CreatedAt,
+		UpdatedAt: h.UpdatedAt,
+	}
+}
+
+func RoleToModel(input admin.Role) Role {
+	permissions := make([]RolePermission, 0, len(input.Permissions))
+	for _, permission := range input.Permissions {
+		permissions = append(permissions, RolePermission{Permission: permission})
+	}
+	return Role{
+		Name:        input.Name,
+		Permissions: permissions,
+	}
+}
+
+func (r Role) ModelToRole() admin.Role {
+	permissions := make([]string, 0, len(r.Permissions))
+	for _, permission := range r.Permissions {
+		permissions = append(permissions, permission.Permission)
+	}
+	return admin.Role{
+		ID:          r.ID,
+		Name:        r.Name,
+		Permissions: permissions,
+		CreatedAt:   r.CreatedAt,
+		UpdatedAt:   r.UpdatedAt,
 	}
 }
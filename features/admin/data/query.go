@@ -3,23 +3,25 @@ package data
 import (
 	"errors"
 	"jastip-jakarta/features/admin"
-	"jastip-jakarta/utils/cloudinary"
+	"jastip-jakarta/utils/storage"
+	"log"
 	"mime/multipart"
 	"strconv"
 	"strings"
+	"time"
 
 	"gorm.io/gorm"
 )
 
 type adminQuery struct {
-	db  *gorm.DB
-	cld cloudinary.CloudinaryUploaderInterface
+	db       *gorm.DB
+	uploader storage.UploaderInterface
 }
 
-func New(db *gorm.DB, cloudinaryUploader cloudinary.CloudinaryUploaderInterface) admin.AdminDataInterface {
+func New(db *gorm.DB, uploader storage.UploaderInterface) admin.AdminDataInterface {
 	return &adminQuery{
-		db:  db,
-		cld: cloudinaryUploader,
+		db:       db,
+		uploader: uploader,
 	}
 }
 
@@ -80,7 +82,12 @@ func (u *adminQuery) SelectById(adminIdLogin int) (*admin.Admin, error) {
 
 // Update implements admin.AdminDataInterface.
 func (u *adminQuery) Update(adminIdLogin int, photo *multipart.FileHeader) error {
-	imageURL, err := u.cld.UploadImage(photo)
+	var current Admin
+	if err := u.db.First(&current, adminIdLogin).Error; err != nil {
+		return err
+	}
+
+	imageURL, err := u.uploader.UploadImage(photo)
 	if err != nil {
 		return err
 	}
@@ -90,11 +97,34 @@ func (u *adminQuery) Update(adminIdLogin int, photo *multipart.FileHeader) error
 
 	tx := u.db.Model(&Admin{}).Where("id = ?", adminIdLogin).Updates(dataGorm)
 	if tx.Error != nil {
+		u.uploader.DeleteImage(imageURL)
 		return tx.Error
 	}
+
+	// Foto lama dihapus dari storage setelah foto baru tersimpan
+	if err := u.uploader.DeleteImage(current.PhotoProfile); err != nil {
+		log.Printf("error deleting old admin photo %s: %v", current.PhotoProfile, err)
+	}
 	return nil
 }
 
+// DeletePhoto implements admin.AdminDataInterface.
+func (u *adminQuery) DeletePhoto(adminIdLogin int) error {
+	var current Admin
+	if err := u.db.First(&current, adminIdLogin).Error; err != nil {
+		return err
+	}
+	if current.PhotoProfile == "" {
+		return errors.New("tidak ada foto profil")
+	}
+
+	tx := u.db.Model(&Admin{}).Where("id = ?", adminIdLogin).Update("photo_profile", "")
+	if tx.Error != nil {
+		return tx.Error
+	}
+	return u.uploader.DeleteImage(current.PhotoProfile)
+}
+
 // InsertRegionCode implements admin.AdminDataInterface.
 func (u *adminQuery) InsertRegionCode(input admin.RegionCode) error {
 	dataGorm := RegionCodeToModel(input)
@@ -235,3 +265,272 @@ func (u *adminQuery) UpdateRegionCode(code string, updatedRegion admin.RegionCod
 	}
 	return nil
 }
+
+// InsertHoliday implements admin.AdminDataInterface.
+func (u *adminQuery) InsertHoliday(input admin.Holiday) (uint, error) {
+	var holidayCheck Holiday
+	result := u.db.Where("date = ?", input.Date.Format("2006-01-02")).First(&holidayCheck)
+	if result.RowsAffected > 0 {
+		return 0, errors.New("tanggal libur sudah terdaftar")
+	}
+
+	dataGorm := HolidayToModel(input)
+	tx := u.db.Create(&dataGorm)
+	if tx.Error != nil {
+		return 0, tx.Error
+	}
+	return dataGorm.ID, nil
+}
+
+// SelectAllHoliday implements admin.AdminDataInterface.
+func (u *adminQuery) SelectAllHoliday() ([]admin.Holiday, error) {
+	var holidays []Holiday
+	err := u.db.Order("date ASC").Find(&holidays).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var responseHolidays []admin.Holiday
+	for _, holiday := range holidays {
+		responseHolidays = append(responseHolidays, holiday.ModelToHoliday())
+	}
+	return responseHolidays, nil
+}
+
+// DeleteHoliday implements admin.AdminDataInterface.
+func (u *adminQuery) DeleteHoliday(holidayId uint) error {
+	tx := u.db.Delete(&Holiday{}, holidayId)
+	if tx.Error != nil {
+		return tx.Error
+	}
+	if tx.RowsAffected == 0 {
+		return errors.New("hari libur tidak ditemukan")
+	}
+	return nil
+}
+
+// SelectReferencedPhotos implements admin.AdminDataInterface.
+// Mengumpulkan semua URL atau key foto yang masih dipakai oleh data aktif.
+func (u *adminQuery) SelectReferencedPhotos() ([]string, error) {
+	sources := []struct {
+		table  string
+		column string
+	}{
+		{"admins", "photo_profile"},
+		{"users", "photo_profile"},
+		{"photo_orders", "photo_packed"},
+		{"photo_orders", "photo_received"},
+		{"condition_photos", "photo"},
+		{"order_pickups", "photo"},
+	}
+
+	var urls []string
+	for _, source := range sources {
+		var values []string
+		err := u.db.Table(source.table).
+			Where("deleted_at IS NULL AND "+source.column+" <> ''").
+			Pluck(source.column, &values).Error
+		if err != nil {
+			return nil, err
+		}
+		urls = append(urls, values...)
+	}
+	return urls, nil
+}
+
+// InsertRole implements admin.AdminDataInterface.
+func (u *adminQuery) InsertRole(input admin.Role) error {
+	var roleCheck Role
+	result := u.db.Where("name = ?", input.Name).Limit(1).Find(&roleCheck)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected > 0 {
+		return errors.New("role sudah terdaftar")
+	}
+
+	dataGorm := RoleToModel(input)
+	tx := u.db.Create(&dataGorm)
+	if tx.Error != nil {
+		return tx.Error
+	}
+	return nil
+}
+
+// SelectAllRoles implements admin.AdminDataInterface.
+func (u *adminQuery) SelectAllRoles() ([]admin.Role, error) {
+	var roles []Role
+	err := u.db.Preload("Permissions").Order("name ASC").Find(&roles).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var responseRoles []admin.Role
+	for _, role := range roles {
+		responseRoles = append(responseRoles, role.ModelToRole())
+	}
+	return responseRoles, nil
+}
+
+// SelectRoleByName implements admin.AdminDataInterface.
+func (u *adminQuery) SelectRoleByName(name string) (*admin.Role, error) {
+	var roleGorm Role
+	err := u.db.Preload("Permissions").Where("name = ?", name).First(&roleGorm).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("role tidak ditemukan")
+		}
+		return nil, err
+	}
+	role := roleGorm.ModelToRole()
+	return &role, nil
+}
+
+// UpdateRolePermissions implements admin.AdminDataInterface.
+// Permission lama diganti seluruhnya dengan daftar yang baru.
+func (u *adminQuery) UpdateRolePermissions(name string, permissions []string) error {
+	return u.db.Transaction(func(tx *gorm.DB) error {
+		var roleGorm Role
+		if err := tx.Where("name = ?", name).First(&roleGorm).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("role tidak ditemukan")
+			}
+			return err
+		}
+
+		if err := tx.Where("role_id = ?", roleGorm.ID).Delete(&RolePermission{}).Error; err != nil {
+			return err
+		}
+
+		for _, permission := range permissions {
+			if err := tx.Create(&RolePermission{RoleID: roleGorm.ID, Permission: permission}).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Model(&roleGorm).Update("updated_at", time.Now()).Error
+	})
+}
+
+// DeleteRole implements admin.AdminDataInterface.
+// Role dihapus permanen agar namanya bisa dipakai lagi.
+func (u *adminQuery) DeleteRole(name string) error {
+	return u.db.Transaction(func(tx *gorm.DB) error {
+		var roleGorm Role
+		if err := tx.Where("name = ?", name).First(&roleGorm).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("role tidak ditemukan")
+			}
+			return err
+		}
+
+		if err := tx.Where("role_id = ?", roleGorm.ID).Delete(&RolePermission{}).Error; err != nil {
+			return err
+		}
+		return tx.Unscoped().Delete(&roleGorm).Error
+	})
+}
+
+// UpdatePassword implements admin.AdminDataInterface.
+func (u *adminQuery) UpdatePassword(adminId uint, hashedPassword string) error {
+	tx := u.db.Model(&Admin{}).Where("id = ?", adminId).Update("password", hashedPassword)
+	if tx.Error != nil {
+		return tx.Error
+	}
+	if tx.RowsAffected == 0 {
+		return errors.New("admin tidak ditemukan")
+	}
+	return nil
+}
+
+// UpdateTwoFactor implements admin.AdminDataInterface.
+func (u *adminQuery) UpdateTwoFactor(adminId uint, secret string, enabled bool) error {
+	tx := u.db.Model(&Admin{}).Where("id = ?", adminId).Updates(map[string]interface{}{
+		"two_factor_secret":  secret,
+		"two_factor_enabled": enabled,
+	})
+	if tx.Error != nil {
+		return tx.Error
+	}
+	return nil
+}
+
+// ReplaceRecoveryCodes implements admin.AdminDataInterface.
+// Recovery code lama dihapus sehingga hanya kode terbaru yang berlaku.
+func (u *adminQuery) ReplaceRecoveryCodes(adminId uint, codeHashes []string) error {
+	return u.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("admin_id = ?", adminId).Delete(&AdminRecoveryCode{}).Error
+		if err != nil {
+			return err
+		}
+		if len(codeHashes) == 0 {
+			return nil
+		}
+
+		var codes []AdminRecoveryCode
+		for _, codeHash := range codeHashes {
+			codes = append(codes, AdminRecoveryCode{AdminID: adminId, CodeHash: codeHash})
+		}
+		return tx.Create(&codes).Error
+	})
+}
+
+// UseRecoveryCode implements admin.AdminDataInterface.
+func (u *adminQuery) UseRecoveryCode(adminId uint, codeHash string) error {
+	tx := u.db.Model(&AdminRecoveryCode{}).
+		Where("admin_id = ? AND code_hash = ? AND used_at IS NULL", adminId, codeHash).
+		Limit(1).
+		Update("used_at", time.Now())
+	if tx.Error != nil {
+		return tx.Error
+	}
+	if tx.RowsAffected == 0 {
+		return errors.New("recovery code tidak valid")
+	}
+	return nil
+}
+
+// UseTwoFactorStep implements admin.AdminDataInterface.
+// Step hanya bisa maju, sehingga kode TOTP yang sama tidak bisa dipakai dua kali.
+func (u *adminQuery) UseTwoFactorStep(adminId uint, step int64) error {
+	tx := u.db.Model(&Admin{}).
+		Where("id = ? AND two_factor_last_step < ?", adminId, step).
+		UpdateColumn("two_factor_last_step", step)
+	if tx.Error != nil {
+		return tx.Error
+	}
+	if tx.RowsAffected == 0 {
+		return errors.New("kode 2FA sudah dipakai")
+	}
+	return nil
+}
+
+// UpdateDeactivatedAt implements admin.AdminDataInterface.
+func (u *adminQuery) UpdateDeactivatedAt(adminId uint, deactivatedAt *time.Time) error {
+	tx := u.db.Model(&Admin{}).Where("id = ?", adminId).Update("deactivated_at", deactivatedAt)
+	if tx.Error != nil {
+		return tx.Error
+	}
+	return nil
+}
+
+// UpdateRole implements admin.AdminDataInterface.
+func (u *adminQuery) UpdateRole(adminId uint, role string) error {
+	tx := u.db.Model(&Admin{}).Where("id = ?", adminId).Update("role", role)
+	if tx.Error != nil {
+		return tx.Error
+	}
+	return nil
+}
+
+// Delete implements admin.AdminDataInterface.
+// Admin dihapus secara soft delete sehingga riwayat order yang ditanganinya tetap utuh.
+func (u *adminQuery) Delete(adminId uint) error {
+	tx := u.db.Where("id = ?", adminId).Delete(&Admin{})
+	if tx.Error != nil {
+		return tx.Error
+	}
+	if tx.RowsAffected == 0 {
+		return errors.New("admin tidak ditemukan")
+	}
+	return nil
+}
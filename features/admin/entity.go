@@ -1,6 +1,8 @@
 package admin
 
 import (
+	"errors"
+	"jastip-jakarta/features/auth"
 	"jastip-jakarta/features/user"
 	"mime/multipart"
 	"time"
@@ -17,6 +19,19 @@ type Admin struct {
 	CreatedAt    time.Time
 	UpdatedAt    time.Time
 	RegionCodes  RegionCode
+
+	// secret TOTP, terisi sejak enrollment dan baru berlaku setelah dikonfirmasi
+	TwoFactorSecret  string
+	TwoFactorEnabled bool
+
+	// admin yang dinonaktifkan tidak bisa login dan semua session-nya dicabut
+	DeactivatedAt *time.Time
+}
+
+// TwoFactorEnrollment berisi secret TOTP baru untuk ditambahkan ke aplikasi authenticator.
+type TwoFactorEnrollment struct {
+	Secret          string
+	ProvisioningURI string
 }
 
 type RegionCode struct {
@@ -25,6 +40,8 @@ type RegionCode struct {
 	FullAddress string
 	PhoneNumber int
 	Price       int
+	MinLeadDays int
+	MaxLeadDays int
 	AdminID     uint
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
@@ -36,6 +53,111 @@ type DeliveryBatch struct {
 	Year      int
 	Month     int
 	AdminID   uint
+	ShippedAt *time.Time
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type Role struct {
+	ID          uint
+	Name        string
+	Permissions []string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// permission yang bisa diberikan ke role, dideklarasikan per route di router
+const (
+	PermissionAll          = "*"
+	PermissionAdminManage  = "admin.manage"
+	PermissionRoleManage   = "role.manage"
+	PermissionUserManage   = "user.manage"
+	PermissionRegionManage = "region.manage"
+	PermissionBatchManage  = "batch.manage"
+	PermissionHoliday      = "holiday.manage"
+	PermissionStorage      = "storage.manage"
+	PermissionOrderRead    = "order.read"
+	PermissionOrderReceive = "order.receive"
+	PermissionOrderDeliver = "order.deliver"
+	PermissionOrderUpdate  = "order.update"
+	PermissionEstimate     = "order.estimate"
+	PermissionOrderStats   = "order.stats"
+	PermissionWarehouse    = "warehouse.manage"
+	PermissionDocument     = "document.print"
+	PermissionPhotoRead    = "photo.read"
+	PermissionPhotoReadAll = "photo.read.all"
+	PermissionLoginManage  = "login.manage"
+	PermissionRegionAll    = "region.all"
+	PermissionAPIKey       = "apikey.manage"
+	PermissionAuditRead    = "audit.read"
+)
+
+// Permissions berisi semua permission yang dikenal aplikasi.
+var Permissions = []string{
+	PermissionAdminManage,
+	PermissionRoleManage,
+	PermissionUserManage,
+	PermissionRegionManage,
+	PermissionBatchManage,
+	PermissionHoliday,
+	PermissionStorage,
+	PermissionOrderRead,
+	PermissionOrderReceive,
+	PermissionOrderDeliver,
+	PermissionOrderUpdate,
+	PermissionEstimate,
+	PermissionOrderStats,
+	PermissionWarehouse,
+	PermissionDocument,
+	PermissionPhotoRead,
+	PermissionPhotoReadAll,
+	PermissionLoginManage,
+	PermissionRegionAll,
+	PermissionAPIKey,
+	PermissionAuditRead,
+}
+
+// TwoFactorPermissions hanya bisa dipakai admin yang sudah mengaktifkan 2FA.
+var TwoFactorPermissions = map[string]bool{
+	PermissionAPIKey:    true,
+	PermissionAuditRead: true,
+}
+
+// RoleSuper adalah role dengan seluruh permission. Admin dengan role ini wajib mengaktifkan 2FA
+// sebelum bisa memakai permission-nya.
+const RoleSuper = "Super"
+
+// ErrTwoFactorRequired dikembalikan saat login admin yang 2FA-nya aktif tanpa menyertakan kode.
+var ErrTwoFactorRequired = errors.New("kode autentikasi dua langkah diperlukan")
+
+// ErrInvalidTwoFactor dikembalikan jika kode TOTP atau recovery code salah.
+var ErrInvalidTwoFactor = errors.New("kode autentikasi dua langkah tidak valid")
+
+// DefaultRoles adalah role bawaan yang dibuat saat aplikasi pertama kali berjalan.
+var DefaultRoles = []Role{
+	{Name: RoleSuper, Permissions: []string{PermissionAll}},
+	{Name: "Jakarta", Permissions: []string{
+		PermissionBatchManage,
+		PermissionOrderRead,
+		PermissionOrderReceive,
+		PermissionEstimate,
+		PermissionWarehouse,
+		PermissionDocument,
+		PermissionPhotoRead,
+		PermissionRegionAll,
+	}},
+	{Name: "Perwakilan", Permissions: []string{
+		PermissionOrderRead,
+		PermissionOrderDeliver,
+		PermissionEstimate,
+		PermissionPhotoRead,
+	}},
+}
+
+type Holiday struct {
+	ID        uint
+	Date      time.Time
+	Name      string
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
@@ -56,15 +178,33 @@ type AdminDataInterface interface {
 	SelectAdminsByRole(role string) ([]Admin, error)
 	SearchRegionCode(code string) ([]RegionCode, error)
 	UpdateRegionCode(code string, updatedRegion RegionCode) error
+	InsertHoliday(input Holiday) (uint, error)
+	SelectAllHoliday() ([]Holiday, error)
+	DeleteHoliday(holidayId uint) error
+	DeletePhoto(adminIdLogin int) error
+	SelectReferencedPhotos() ([]string, error)
+	InsertRole(input Role) error
+	SelectAllRoles() ([]Role, error)
+	SelectRoleByName(name string) (*Role, error)
+	UpdateRolePermissions(name string, permissions []string) error
+	DeleteRole(name string) error
+	UpdatePassword(adminId uint, hashedPassword string) error
+	UpdateTwoFactor(adminId uint, secret string, enabled bool) error
+	ReplaceRecoveryCodes(adminId uint, codeHashes []string) error
+	UseRecoveryCode(adminId uint, codeHash string) error
+	UseTwoFactorStep(adminId uint, step int64) error
+	UpdateDeactivatedAt(adminId uint, deactivatedAt *time.Time) error
+	UpdateRole(adminId uint, role string) error
+	Delete(adminId uint) error
 }
 
 // interface untuk Service Layer
 type AdminServiceInterface interface {
-	CreateSuper(input Admin) error
+	SetupSuper(setupToken string, input Admin) error
 	Create(adminIdLogin int, input Admin) error
 	GetById(adminIdLogin int) (*Admin, error)
 	Update(adminIdLogin int, photo *multipart.FileHeader) error
-	Login(phoneOrEmail, password string) (data *Admin, token string, err error)
+	Login(phoneOrEmail, password, otpCode string, client auth.ClientInfo) (data *Admin, token *auth.Token, err error)
 	CreateRegionCode(adminIdLogin int, input RegionCode) error
 	GetAllRegionCode() ([]RegionCode, error)
 	GettByIdRegion(IdRegion string) (*RegionCode, error)
@@ -79,4 +219,30 @@ type AdminServiceInterface interface {
 	UpdateUserByName(adminIdLogin int, name string, input user.User) error
 	GetAllUser(adminIdLogin int) ([]user.User, error)
 	CreateUser(adminIdLogin int, input user.User) error
+	CreateHoliday(adminIdLogin int, input Holiday) (uint, error)
+	GetAllHoliday() ([]Holiday, error)
+	DeleteHoliday(adminIdLogin int, holidayId uint) error
+	DeletePhoto(adminIdLogin int) error
+	CleanupOrphanPhotos(adminIdLogin int, dryRun bool) ([]string, error)
+	StartOrphanCleanupJob(interval time.Duration)
+	SeedDefaultRoles() error
+	HasPermission(adminIdLogin int, permission string) (bool, error)
+	CreateRole(adminIdLogin int, input Role) error
+	GetAllRoles() ([]Role, error)
+	UpdateRole(adminIdLogin int, name string, permissions []string) error
+	DeleteRole(name string) error
+	RequestPasswordReset(phoneOrEmail string) error
+	ResetPassword(phoneOrEmail, code, newPassword string) error
+	ChangePassword(adminIdLogin int, sessionId, currentPassword, newPassword string) error
+	SetUserVerification(name string, emailVerified, phoneVerified *bool) error
+	EnrollTwoFactor(adminIdLogin int) (*TwoFactorEnrollment, error)
+	ConfirmTwoFactor(adminIdLogin int, code string) ([]string, error)
+	RegenerateRecoveryCodes(adminIdLogin int, code string) ([]string, error)
+	DisableTwoFactor(adminIdLogin int, code string) error
+	ResetTwoFactor(adminIdLogin int, adminId int) error
+	DeactivateAdmin(adminIdLogin int, adminId int) error
+	ActivateAdmin(adminIdLogin int, adminId int) error
+	ChangeAdminRole(adminIdLogin int, adminId int, role string) error
+	ForcePasswordReset(adminIdLogin int, adminId int) error
+	DeleteAdmin(adminIdLogin int, adminId int) error
 }
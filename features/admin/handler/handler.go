@@ -1,8 +1,12 @@
 package handler
 
 import (
+	"errors"
 	"jastip-jakarta/features/admin"
+	"jastip-jakarta/features/auth"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"jastip-jakarta/utils/middlewares"
 	"jastip-jakarta/utils/responses"
@@ -20,7 +24,7 @@ func New(service admin.AdminServiceInterface) *AdminHandler {
 	}
 }
 
-func (handler *AdminHandler) RegisterAdminSuper(c echo.Context) error {
+func (handler *AdminHandler) SetupAdminSuper(c echo.Context) error {
 	newAdmin := AdminRequest{}
 	errBind := c.Bind(&newAdmin)
 	if errBind != nil {
@@ -28,16 +32,17 @@ func (handler *AdminHandler) RegisterAdminSuper(c echo.Context) error {
 	}
 
 	adminCore := RequestToAdmin(newAdmin)
-	errInsert := handler.adminService.CreateSuper(adminCore)
+	errInsert := handler.adminService.SetupSuper(c.Request().Header.Get("X-Setup-Token"), adminCore)
 	if errInsert != nil {
-		return c.JSON(http.StatusInternalServerError, responses.WebResponse(errInsert.Error(), nil))
+		return c.JSON(http.StatusForbidden, responses.WebResponse(errInsert.Error(), nil))
 	}
+	middlewares.SetAuditEntity(c, strconv.Itoa(int(adminCore.ID)))
 
 	return c.JSON(http.StatusOK, responses.WebResponse("Membuat Akun Admin Berhasil", nil))
 }
 
 func (handler *AdminHandler) RegisterAdmin(c echo.Context) error {
-	adminIdLogin := middlewares.ExtractTokenUserId(c)
+	adminIdLogin := middlewares.ExtractTokenAdminId(c)
 	newAdmin := AdminRequest{}
 	errBind := c.Bind(&newAdmin)
 	if errBind != nil {
@@ -49,12 +54,13 @@ func (handler *AdminHandler) RegisterAdmin(c echo.Context) error {
 	if errInsert != nil {
 		return c.JSON(http.StatusInternalServerError, responses.WebResponse(errInsert.Error(), nil))
 	}
+	middlewares.SetAuditEntity(c, strconv.Itoa(int(adminCore.ID)))
 
 	return c.JSON(http.StatusOK, responses.WebResponse("Membuat Akun Admin Berhasil", nil))
 }
 
 func (handler *AdminHandler) GetAdmin(c echo.Context) error {
-	adminIdLogin := middlewares.ExtractTokenUserId(c)
+	adminIdLogin := middlewares.ExtractTokenAdminId(c)
 
 	result, errSelect := handler.adminService.GetById(adminIdLogin)
 	if errSelect != nil {
@@ -66,7 +72,7 @@ func (handler *AdminHandler) GetAdmin(c echo.Context) error {
 }
 
 func (handler *AdminHandler) UpdateAdmin(c echo.Context) error {
-	adminIdLogin := middlewares.ExtractTokenUserId(c)
+	adminIdLogin := middlewares.ExtractTokenAdminId(c)
 
 	fileHeader, err := c.FormFile("photo_profile")
 	if err != nil && err != http.ErrMissingFile {
@@ -87,20 +93,26 @@ func (handler *AdminHandler) Login(c echo.Context) error {
 	if errBind != nil {
 		return c.JSON(http.StatusBadRequest, responses.WebResponse("error bind data, data not valid", nil))
 	}
-	result, token, err := handler.adminService.Login(reqData.EmailOrPhone, reqData.Password)
+	result, token, err := handler.adminService.Login(reqData.EmailOrPhone, reqData.Password, reqData.OTPCode, auth.ClientInfo{
+		UserAgent: c.Request().UserAgent(),
+		IPAddress: c.RealIP(),
+	})
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, responses.WebResponse(err.Error(), nil))
+		return c.JSON(loginErrorStatus(err), responses.WebResponse(err.Error(), nil))
 	}
 	responseData := map[string]any{
-		"token": token,
-		"nama":  result.Name,
-		"role":  result.Role,
+		"token":              token.AccessToken,
+		"refresh_token":      token.RefreshToken,
+		"expires_at":         token.ExpiresAt,
+		"nama":               result.Name,
+		"role":               result.Role,
+		"two_factor_enabled": result.TwoFactorEnabled,
 	}
 	return c.JSON(http.StatusOK, responses.WebResponse("Login berhasil", responseData))
 }
 
 func (handler *AdminHandler) CreateRegionCode(c echo.Context) error {
-	adminIdLogin := middlewares.ExtractTokenUserId(c)
+	adminIdLogin := middlewares.ExtractTokenAdminId(c)
 	newRegion := RegionCodeRequest{}
 	errBind := c.Bind(&newRegion)
 	if errBind != nil {
@@ -112,6 +124,7 @@ func (handler *AdminHandler) CreateRegionCode(c echo.Context) error {
 	if errInsert != nil {
 		return c.JSON(http.StatusInternalServerError, responses.WebResponse(errInsert.Error(), nil))
 	}
+	middlewares.SetAuditEntity(c, regionCore.ID)
 
 	return c.JSON(http.StatusOK, responses.WebResponse("Membuat Kode WIlayah Berhasil", nil))
 }
@@ -145,7 +158,7 @@ func (handler *AdminHandler) GetRegionCodeById(c echo.Context) error {
 }
 
 func (handler *AdminHandler) CreateDeliveryBatch(c echo.Context) error {
-	adminIdLogin := middlewares.ExtractTokenUserId(c)
+	adminIdLogin := middlewares.ExtractTokenAdminId(c)
 	if adminIdLogin == 0 {
 		return c.JSON(http.StatusUnauthorized, responses.WebResponse("Silahkan login terlebih dahulu", nil))
 	}
@@ -161,6 +174,7 @@ func (handler *AdminHandler) CreateDeliveryBatch(c echo.Context) error {
 	if errInsert != nil {
 		return c.JSON(http.StatusInternalServerError, responses.WebResponse(errInsert.Error(), nil))
 	}
+	middlewares.SetAuditEntity(c, batchCore.ID)
 
 	return c.JSON(http.StatusOK, responses.WebResponse("Membuat Batch Baru Berhasil", nil))
 }
@@ -192,7 +206,7 @@ func (handler *AdminHandler) GetDeliveryBatchById(c echo.Context) error {
 }
 
 func (handler *AdminHandler) GetAdminJakarta(c echo.Context) error {
-	adminIdLogin := middlewares.ExtractTokenUserId(c)
+	adminIdLogin := middlewares.ExtractTokenAdminId(c)
 	if adminIdLogin == 0 {
 		return c.JSON(http.StatusUnauthorized, responses.WebResponse("Silahkan login terlebih dahulu", nil))
 	}
@@ -212,7 +226,7 @@ func (handler *AdminHandler) GetAdminJakarta(c echo.Context) error {
 }
 
 func (handler *AdminHandler) GetAdminPerwakilan(c echo.Context) error {
-	adminIdLogin := middlewares.ExtractTokenUserId(c)
+	adminIdLogin := middlewares.ExtractTokenAdminId(c)
 	if adminIdLogin == 0 {
 		return c.JSON(http.StatusUnauthorized, responses.WebResponse("Silahkan login terlebih dahulu", nil))
 	}
@@ -232,7 +246,7 @@ func (handler *AdminHandler) GetAdminPerwakilan(c echo.Context) error {
 }
 
 func (handler *AdminHandler) GetAllAdmin(c echo.Context) error {
-	adminIdLogin := middlewares.ExtractTokenUserId(c)
+	adminIdLogin := middlewares.ExtractTokenAdminId(c)
 	if adminIdLogin == 0 {
 		return c.JSON(http.StatusUnauthorized, responses.WebResponse("Silahkan login terlebih dahulu", nil))
 	}
@@ -251,7 +265,7 @@ func (handler *AdminHandler) GetAllAdmin(c echo.Context) error {
 }
 
 func (handler *AdminHandler) SearchRegionCode(c echo.Context) error {
-	adminIdLogin := middlewares.ExtractTokenUserId(c)
+	adminIdLogin := middlewares.ExtractTokenAdminId(c)
 	if adminIdLogin == 0 {
 		return c.JSON(http.StatusUnauthorized, responses.WebResponse("Silahkan login terlebih dahulu", nil))
 	}
@@ -272,7 +286,7 @@ func (handler *AdminHandler) SearchRegionCode(c echo.Context) error {
 }
 
 func (handler *AdminHandler) UpdateRegionCode(c echo.Context) error {
-	adminIdLogin := middlewares.ExtractTokenUserId(c)
+	adminIdLogin := middlewares.ExtractTokenAdminId(c)
 	if adminIdLogin == 0 {
 		return c.JSON(http.StatusUnauthorized, responses.WebResponse("Silahkan login terlebih dahulu", nil))
 	}
@@ -298,7 +312,7 @@ func (handler *AdminHandler) UpdateRegionCode(c echo.Context) error {
 }
 
 func (handler *AdminHandler) SearchUser(c echo.Context) error {
-    adminIdLogin := middlewares.ExtractTokenUserId(c)
+    adminIdLogin := middlewares.ExtractTokenAdminId(c)
     if adminIdLogin == 0 {
         return c.JSON(http.StatusUnauthorized, responses.WebResponse("Silahkan login terlebih dahulu", nil))
     }
@@ -322,7 +336,7 @@ func (handler *AdminHandler) SearchUser(c echo.Context) error {
 }
 
 func (handler *AdminHandler) UpdateUserByName(c echo.Context) error {
-    adminIdLogin := middlewares.ExtractTokenUserId(c)
+    adminIdLogin := middlewares.ExtractTokenAdminId(c)
     if adminIdLogin == 0 {
         return c.JSON(http.StatusUnauthorized, responses.WebResponse("Silahkan login terlebih dahulu", nil))
     }
@@ -349,7 +363,7 @@ func (handler *AdminHandler) UpdateUserByName(c echo.Context) error {
 }
 
 func (handler *AdminHandler) CreateUser(c echo.Context) error {
-	adminIdLogin := middlewares.ExtractTokenUserId(c)
+	adminIdLogin := middlewares.ExtractTokenAdminId(c)
     if adminIdLogin == 0 {
         return c.JSON(http.StatusUnauthorized, responses.WebResponse("Silahkan login terlebih dahulu", nil))
     }
@@ -365,12 +379,13 @@ func (handler *AdminHandler) CreateUser(c echo.Context) error {
 	if errInsert != nil {
 		return c.JSON(http.StatusInternalServerError, responses.WebResponse(errInsert.Error(), nil))
 	}
+	middlewares.SetAuditEntity(c, strconv.Itoa(int(userCore.ID)))
 
 	return c.JSON(http.StatusOK, responses.WebResponse("Pendaftaran Berhasil", nil))
 }
 
 func (handler *AdminHandler) GetAllUSer(c echo.Context) error {
-	adminIdLogin := middlewares.ExtractTokenUserId(c)
+	adminIdLogin := middlewares.ExtractTokenAdminId(c)
     if adminIdLogin == 0 {
         return c.JSON(http.StatusUnauthorized, responses.WebResponse("Silahkan login terlebih dahulu", nil))
     }
@@ -386,4 +401,409 @@ func (handler *AdminHandler) GetAllUSer(c echo.Context) error {
 	}
 
 	return c.JSON(http.StatusOK, responses.WebResponse("Berhasil mengambil semua user", userResps))
-}
\ No newline at end of file
+}
+
+func (handler *AdminHandler) CreateHoliday(c echo.Context) error {
+	adminIdLogin := middlewares.ExtractTokenAdminId(c)
+	if adminIdLogin == 0 {
+		return c.JSON(http.StatusUnauthorized, responses.WebResponse("Silahkan login terlebih dahulu", nil))
+	}
+
+	newHoliday := HolidayRequest{}
+	errBind := c.Bind(&newHoliday)
+	if errBind != nil {
+		return c.JSON(http.StatusBadRequest, responses.WebResponse("error bind data, data not valid", nil))
+	}
+
+	holidayCore, errParse := RequestToHoliday(newHoliday)
+	if errParse != nil {
+		return c.JSON(http.StatusBadRequest, responses.WebResponse("Format tanggal tidak valid. Gunakan format dd/mm/yyyy", nil))
+	}
+
+	holidayId, errInsert := handler.adminService.CreateHoliday(adminIdLogin, holidayCore)
+	if errInsert != nil {
+		return c.JSON(http.StatusInternalServerError, responses.WebResponse(errInsert.Error(), nil))
+	}
+	middlewares.SetAuditEntity(c, strconv.Itoa(int(holidayId)))
+
+	return c.JSON(http.StatusOK, responses.WebResponse("Berhasil menambahkan hari libur", nil))
+}
+
+func (handler *AdminHandler) GetAllHoliday(c echo.Context) error {
+	holidays, err := handler.adminService.GetAllHoliday()
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, responses.WebResponse(err.Error(), nil))
+	}
+
+	var holidayResponses []HolidayResponse
+	for _, holiday := range holidays {
+		holidayResponses = append(holidayResponses, CoreToResponseHoliday(holiday))
+	}
+
+	return c.JSON(http.StatusOK, responses.WebResponse("Berhasil mengambil hari libur", holidayResponses))
+}
+
+func (handler *AdminHandler) DeleteHoliday(c echo.Context) error {
+	adminIdLogin := middlewares.ExtractTokenAdminId(c)
+	if adminIdLogin == 0 {
+		return c.JSON(http.StatusUnauthorized, responses.WebResponse("Silahkan login terlebih dahulu", nil))
+	}
+
+	holidayId, errParse := strconv.ParseUint(c.Param("holiday_id"), 10, 32)
+	if errParse != nil {
+		return c.JSON(http.StatusBadRequest, responses.WebResponse("ID hari libur tidak valid", nil))
+	}
+
+	errDelete := handler.adminService.DeleteHoliday(adminIdLogin, uint(holidayId))
+	if errDelete != nil {
+		return c.JSON(http.StatusInternalServerError, responses.WebResponse(errDelete.Error(), nil))
+	}
+
+	return c.JSON(http.StatusOK, responses.WebResponse("Berhasil menghapus hari libur", nil))
+}
+
+func (handler *AdminHandler) DeletePhoto(c echo.Context) error {
+	adminIdLogin := middlewares.ExtractTokenAdminId(c)
+	if adminIdLogin == 0 {
+		return c.JSON(http.StatusUnauthorized, responses.WebResponse("Silahkan login terlebih dahulu", nil))
+	}
+
+	err := handler.adminService.DeletePhoto(adminIdLogin)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, responses.WebResponse(err.Error(), nil))
+	}
+
+	return c.JSON(http.StatusOK, responses.WebResponse("Berhasil menghapus foto profil", nil))
+}
+
+func (handler *AdminHandler) CleanupOrphanPhotos(c echo.Context) error {
+	adminIdLogin := middlewares.ExtractTokenAdminId(c)
+	if adminIdLogin == 0 {
+		return c.JSON(http.StatusUnauthorized, responses.WebResponse("Silahkan login terlebih dahulu", nil))
+	}
+
+	dryRun := c.QueryParam("dry_run") == "true"
+
+	removed, err := handler.adminService.CleanupOrphanPhotos(adminIdLogin, dryRun)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, responses.WebResponse(err.Error(), nil))
+	}
+
+	message := "Berhasil menghapus foto yang tidak terpakai"
+	if dryRun {
+		message = "Daftar foto yang tidak terpakai"
+	}
+	return c.JSON(http.StatusOK, responses.WebResponse(message, OrphanCleanupResponse{
+		DryRun: dryRun,
+		Total:  len(removed),
+		Keys:   removed,
+	}))
+}
+
+func (handler *AdminHandler) CreateRole(c echo.Context) error {
+	adminIdLogin := middlewares.ExtractTokenAdminId(c)
+	newRole := RoleRequest{}
+	errBind := c.Bind(&newRole)
+	if errBind != nil {
+		return c.JSON(http.StatusBadRequest, responses.WebResponse("error bind data: "+errBind.Error(), nil))
+	}
+
+	errInsert := handler.adminService.CreateRole(adminIdLogin, admin.Role{
+		Name:        newRole.Name,
+		Permissions: newRole.Permissions,
+	})
+	if errInsert != nil {
+		return c.JSON(http.StatusInternalServerError, responses.WebResponse(errInsert.Error(), nil))
+	}
+	middlewares.SetAuditEntity(c, strings.TrimSpace(newRole.Name))
+
+	return c.JSON(http.StatusOK, responses.WebResponse("Berhasil menambahkan role", nil))
+}
+
+func (handler *AdminHandler) GetAllRoles(c echo.Context) error {
+	roles, err := handler.adminService.GetAllRoles()
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, responses.WebResponse(err.Error(), nil))
+	}
+
+	var roleResponses []RoleResponse
+	for _, role := range roles {
+		roleResponses = append(roleResponses, CoreToResponseRole(role))
+	}
+
+	return c.JSON(http.StatusOK, responses.WebResponse("Berhasil mengambil role", map[string]interface{}{
+		"roles":       roleResponses,
+		"permissions": admin.Permissions,
+	}))
+}
+
+func (handler *AdminHandler) UpdateRole(c echo.Context) error {
+	adminIdLogin := middlewares.ExtractTokenAdminId(c)
+	updateRole := RoleRequest{}
+	errBind := c.Bind(&updateRole)
+	if errBind != nil {
+		return c.JSON(http.StatusBadRequest, responses.WebResponse("error bind data: "+errBind.Error(), nil))
+	}
+
+	errUpdate := handler.adminService.UpdateRole(adminIdLogin, c.Param("name"), updateRole.Permissions)
+	if errUpdate != nil {
+		return c.JSON(http.StatusInternalServerError, responses.WebResponse(errUpdate.Error(), nil))
+	}
+
+	return c.JSON(http.StatusOK, responses.WebResponse("Berhasil mengubah permission role", nil))
+}
+
+func (handler *AdminHandler) DeleteRole(c echo.Context) error {
+	errDelete := handler.adminService.DeleteRole(c.Param("name"))
+	if errDelete != nil {
+		return c.JSON(http.StatusInternalServerError, responses.WebResponse(errDelete.Error(), nil))
+	}
+
+	return c.JSON(http.StatusOK, responses.WebResponse("Berhasil menghapus role", nil))
+}
+
+func (handler *AdminHandler) ForgotPassword(c echo.Context) error {
+	reqData := ForgotPasswordRequest{}
+	errBind := c.Bind(&reqData)
+	if errBind != nil {
+		return c.JSON(http.StatusBadRequest, responses.WebResponse("error bind data, data not valid", nil))
+	}
+
+	err := handler.adminService.RequestPasswordReset(reqData.EmailOrPhone)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, responses.WebResponse(err.Error(), nil))
+	}
+
+	return c.JSON(http.StatusOK, responses.WebResponse("Jika akun terdaftar, kode reset password telah dikirim", nil))
+}
+
+func (handler *AdminHandler) ResetPassword(c echo.Context) error {
+	reqData := ResetPasswordRequest{}
+	errBind := c.Bind(&reqData)
+	if errBind != nil {
+		return c.JSON(http.StatusBadRequest, responses.WebResponse("error bind data, data not valid", nil))
+	}
+
+	err := handler.adminService.ResetPassword(reqData.EmailOrPhone, reqData.Code, reqData.NewPassword)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, responses.WebResponse(err.Error(), nil))
+	}
+
+	return c.JSON(http.StatusOK, responses.WebResponse("Password berhasil diubah, silahkan login kembali", nil))
+}
+
+func (handler *AdminHandler) UpdateUserVerification(c echo.Context) error {
+	reqData := UserVerificationRequest{}
+	errBind := c.Bind(&reqData)
+	if errBind != nil {
+		return c.JSON(http.StatusBadRequest, responses.WebResponse("error bind data, data not valid", nil))
+	}
+
+	err := handler.adminService.SetUserVerification(c.Param("name"), reqData.EmailVerified, reqData.PhoneVerified)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, responses.WebResponse(err.Error(), nil))
+	}
+
+	return c.JSON(http.StatusOK, responses.WebResponse("Berhasil mengubah status verifikasi user", nil))
+}
+
+func loginErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, auth.ErrLoginLocked):
+		return http.StatusTooManyRequests
+	case errors.Is(err, auth.ErrInvalidCredentials), errors.Is(err, admin.ErrTwoFactorRequired), errors.Is(err, admin.ErrInvalidTwoFactor):
+		return http.StatusUnauthorized
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func (handler *AdminHandler) EnrollTwoFactor(c echo.Context) error {
+	adminIdLogin := middlewares.ExtractTokenAdminId(c)
+	if adminIdLogin == 0 {
+		return c.JSON(http.StatusUnauthorized, responses.WebResponse("Silahkan login terlebih dahulu", nil))
+	}
+
+	result, err := handler.adminService.EnrollTwoFactor(adminIdLogin)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, responses.WebResponse(err.Error(), nil))
+	}
+
+	return c.JSON(http.StatusOK, responses.WebResponse("Scan QR code lalu konfirmasi dengan kode dari aplikasi authenticator", CoreToTwoFactorEnrollmentResponse(*result)))
+}
+
+func (handler *AdminHandler) ConfirmTwoFactor(c echo.Context) error {
+	adminIdLogin := middlewares.ExtractTokenAdminId(c)
+	if adminIdLogin == 0 {
+		return c.JSON(http.StatusUnauthorized, responses.WebResponse("Silahkan login terlebih dahulu", nil))
+	}
+
+	reqData := TwoFactorCodeRequest{}
+	errBind := c.Bind(&reqData)
+	if errBind != nil {
+		return c.JSON(http.StatusBadRequest, responses.WebResponse("error bind data, data not valid", nil))
+	}
+
+	recoveryCodes, err := handler.adminService.ConfirmTwoFactor(adminIdLogin, reqData.Code)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, responses.WebResponse(err.Error(), nil))
+	}
+
+	return c.JSON(http.StatusOK, responses.WebResponse("2FA berhasil diaktifkan, simpan recovery code di tempat yang aman", RecoveryCodesResponse{RecoveryCodes: recoveryCodes}))
+}
+
+func (handler *AdminHandler) RegenerateRecoveryCodes(c echo.Context) error {
+	adminIdLogin := middlewares.ExtractTokenAdminId(c)
+	if adminIdLogin == 0 {
+		return c.JSON(http.StatusUnauthorized, responses.WebResponse("Silahkan login terlebih dahulu", nil))
+	}
+
+	reqData := TwoFactorCodeRequest{}
+	errBind := c.Bind(&reqData)
+	if errBind != nil {
+		return c.JSON(http.StatusBadRequest, responses.WebResponse("error bind data, data not valid", nil))
+	}
+
+	recoveryCodes, err := handler.adminService.RegenerateRecoveryCodes(adminIdLogin, reqData.Code)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, responses.WebResponse(err.Error(), nil))
+	}
+
+	return c.JSON(http.StatusOK, responses.WebResponse("Recovery code baru berhasil dibuat", RecoveryCodesResponse{RecoveryCodes: recoveryCodes}))
+}
+
+func (handler *AdminHandler) DisableTwoFactor(c echo.Context) error {
+	adminIdLogin := middlewares.ExtractTokenAdminId(c)
+	if adminIdLogin == 0 {
+		return c.JSON(http.StatusUnauthorized, responses.WebResponse("Silahkan login terlebih dahulu", nil))
+	}
+
+	reqData := TwoFactorCodeRequest{}
+	errBind := c.Bind(&reqData)
+	if errBind != nil {
+		return c.JSON(http.StatusBadRequest, responses.WebResponse("error bind data, data not valid", nil))
+	}
+
+	err := handler.adminService.DisableTwoFactor(adminIdLogin, reqData.Code)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, responses.WebResponse(err.Error(), nil))
+	}
+
+	return c.JSON(http.StatusOK, responses.WebResponse("2FA berhasil dinonaktifkan", nil))
+}
+
+func (handler *AdminHandler) ResetTwoFactor(c echo.Context) error {
+	adminIdLogin := middlewares.ExtractTokenAdminId(c)
+	adminId, errConv := strconv.Atoi(c.Param("admin_id"))
+	if errConv != nil {
+		return c.JSON(http.StatusBadRequest, responses.WebResponse("id admin tidak valid", nil))
+	}
+
+	err := handler.adminService.ResetTwoFactor(adminIdLogin, adminId)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, responses.WebResponse(err.Error(), nil))
+	}
+
+	return c.JSON(http.StatusOK, responses.WebResponse("Berhasil mereset 2FA admin", nil))
+}
+
+func (handler *AdminHandler) DeactivateAdmin(c echo.Context) error {
+	adminIdLogin := middlewares.ExtractTokenAdminId(c)
+	adminId, errConv := strconv.Atoi(c.Param("admin_id"))
+	if errConv != nil {
+		return c.JSON(http.StatusBadRequest, responses.WebResponse("id admin tidak valid", nil))
+	}
+
+	err := handler.adminService.DeactivateAdmin(adminIdLogin, adminId)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, responses.WebResponse(err.Error(), nil))
+	}
+
+	return c.JSON(http.StatusOK, responses.WebResponse("Berhasil menonaktifkan admin", nil))
+}
+
+func (handler *AdminHandler) ActivateAdmin(c echo.Context) error {
+	adminIdLogin := middlewares.ExtractTokenAdminId(c)
+	adminId, errConv := strconv.Atoi(c.Param("admin_id"))
+	if errConv != nil {
+		return c.JSON(http.StatusBadRequest, responses.WebResponse("id admin tidak valid", nil))
+	}
+
+	err := handler.adminService.ActivateAdmin(adminIdLogin, adminId)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, responses.WebResponse(err.Error(), nil))
+	}
+
+	return c.JSON(http.StatusOK, responses.WebResponse("Berhasil mengaktifkan kembali admin", nil))
+}
+
+func (handler *AdminHandler) ChangeAdminRole(c echo.Context) error {
+	adminIdLogin := middlewares.ExtractTokenAdminId(c)
+	adminId, errConv := strconv.Atoi(c.Param("admin_id"))
+	if errConv != nil {
+		return c.JSON(http.StatusBadRequest, responses.WebResponse("id admin tidak valid", nil))
+	}
+
+	reqData := AdminRoleRequest{}
+	errBind := c.Bind(&reqData)
+	if errBind != nil {
+		return c.JSON(http.StatusBadRequest, responses.WebResponse("error bind data, data not valid", nil))
+	}
+
+	err := handler.adminService.ChangeAdminRole(adminIdLogin, adminId, reqData.Role)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, responses.WebResponse(err.Error(), nil))
+	}
+
+	return c.JSON(http.StatusOK, responses.WebResponse("Berhasil mengubah role admin", nil))
+}
+
+func (handler *AdminHandler) ForcePasswordReset(c echo.Context) error {
+	adminIdLogin := middlewares.ExtractTokenAdminId(c)
+	adminId, errConv := strconv.Atoi(c.Param("admin_id"))
+	if errConv != nil {
+		return c.JSON(http.StatusBadRequest, responses.WebResponse("id admin tidak valid", nil))
+	}
+
+	err := handler.adminService.ForcePasswordReset(adminIdLogin, adminId)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, responses.WebResponse(err.Error(), nil))
+	}
+
+	return c.JSON(http.StatusOK, responses.WebResponse("Password admin direset, kode reset telah dikirim ke admin tersebut", nil))
+}
+
+func (handler *AdminHandler) DeleteAdmin(c echo.Context) error {
+	adminIdLogin := middlewares.ExtractTokenAdminId(c)
+	adminId, errConv := strconv.Atoi(c.Param("admin_id"))
+	if errConv != nil {
+		return c.JSON(http.StatusBadRequest, responses.WebResponse("id admin tidak valid", nil))
+	}
+
+	err := handler.adminService.DeleteAdmin(adminIdLogin, adminId)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, responses.WebResponse(err.Error(), nil))
+	}
+
+	return c.JSON(http.StatusOK, responses.WebResponse("Berhasil menghapus admin", nil))
+}
+
+func (handler *AdminHandler) ChangePassword(c echo.Context) error {
+	adminIdLogin := middlewares.ExtractTokenAdminId(c)
+	if adminIdLogin == 0 {
+		return c.JSON(http.StatusUnauthorized, responses.WebResponse("Silahkan login terlebih dahulu", nil))
+	}
+
+	reqData := ChangePasswordRequest{}
+	errBind := c.Bind(&reqData)
+	if errBind != nil {
+		return c.JSON(http.StatusBadRequest, responses.WebResponse("error bind data, data not valid", nil))
+	}
+
+	err := handler.adminService.ChangePassword(adminIdLogin, middlewares.ExtractTokenSessionId(c), reqData.CurrentPassword, reqData.NewPassword)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, responses.WebResponse(err.Error(), nil))
+	}
+
+	return c.JSON(http.StatusOK, responses.WebResponse("Password berhasil diubah, perangkat lain telah dikeluarkan", nil))
+}
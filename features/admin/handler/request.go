@@ -21,6 +21,30 @@ type AdminRequest struct {
 type LoginRequest struct {
 	EmailOrPhone string `json:"email_or_phone"`
 	Password     string `json:"password"`
+	OTPCode      string `json:"otp_code"`
+}
+
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password"`
+	NewPassword     string `json:"new_password"`
+}
+
+type AdminRoleRequest struct {
+	Role string `json:"role"`
+}
+
+type TwoFactorCodeRequest struct {
+	Code string `json:"code"`
+}
+
+type ForgotPasswordRequest struct {
+	EmailOrPhone string `json:"email_or_phone"`
+}
+
+type ResetPasswordRequest struct {
+	EmailOrPhone string `json:"email_or_phone"`
+	Code         string `json:"code"`
+	NewPassword  string `json:"new_password"`
 }
 
 type RegionCodeRequest struct {
@@ -29,6 +53,8 @@ type RegionCodeRequest struct {
 	FullAddress string `json:"full_address"`
 	PhoneNumber int    `json:"phone"`
 	Price       int    `json:"price"`
+	MinLeadDays int    `json:"min_lead_days"`
+	MaxLeadDays int    `json:"max_lead_days"`
 	AdminID     uint   `json:"admin_id_perwakilan"`
 }
 
@@ -39,11 +65,30 @@ type DeliveryBatchRequest struct {
 	Month         int `json:"month"`
 }
 
+type HolidayRequest struct {
+	Date string `json:"date"`
+	Name string `json:"name"`
+}
+
+type RoleRequest struct {
+	Name        string   `json:"name"`
+	Permissions []string `json:"permissions"`
+}
+
+type UserVerificationRequest struct {
+	EmailVerified *bool `json:"email_verified"`
+	PhoneVerified *bool `json:"phone_verified"`
+}
+
 type UserRequest struct {
 	Name        string `json:"name" form:"name"`
 	Email       string `json:"email" form:"email"`
 	Password    string `json:"password" form:"password"`
 	PhoneNumber int    `json:"phone" form:"phone"`
+
+	// hanya dipakai saat admin mendaftarkan user yang kontaknya sudah dicek langsung
+	EmailVerified bool `json:"email_verified" form:"email_verified"`
+	PhoneVerified bool `json:"phone_verified" form:"phone_verified"`
 }
 
 func RequestToUser(input UserRequest) uh.User {
@@ -56,13 +101,22 @@ func RequestToUser(input UserRequest) uh.User {
 }
 
 func RequestRegisterToUser(input UserRequest) uh.User {
-	return uh.User{
+	newUser := uh.User{
 		ID: generateIDUSer(),
 		Name:        input.Name,
 		Email:       input.Email,
 		Password:    input.Password,
 		PhoneNumber: input.PhoneNumber,
 	}
+
+	now := time.Now()
+	if input.EmailVerified {
+		newUser.EmailVerifiedAt = &now
+	}
+	if input.PhoneVerified {
+		newUser.PhoneVerifiedAt = &now
+	}
+	return newUser
 }
 
 func RequestToAdmin(input AdminRequest) admin.Admin {
@@ -83,6 +137,8 @@ func RequestToRegionCode(input RegionCodeRequest) admin.RegionCode {
 		FullAddress: input.FullAddress,
 		PhoneNumber: input.PhoneNumber,
 		Price:       input.Price,
+		MinLeadDays: input.MinLeadDays,
+		MaxLeadDays: input.MaxLeadDays,
 		AdminID:     input.AdminID,
 	}
 }
@@ -97,6 +153,18 @@ func RequestToDeliveryBatch(input DeliveryBatchRequest) admin.DeliveryBatch {
 	}
 }
 
+func RequestToHoliday(input HolidayRequest) (admin.Holiday, error) {
+	// Format tanggal dd/mm/yyyy
+	date, err := time.Parse("02/01/2006", input.Date)
+	if err != nil {
+		return admin.Holiday{}, err
+	}
+	return admin.Holiday{
+		Date: date,
+		Name: input.Name,
+	}, nil
+}
+
 func generateID() uint {
 	rand.Seed(time.Now().UnixNano())
 	randomNumber := rand.Int63n(9999999999-1000000000) + 1000000000
@@ -15,12 +15,16 @@ type AdminResponse struct {
 	PhotoProfile string `json:"photo_profile"`
 	CreatedAt    string `json:"create_account"`
 	UpdatedAt    string `json:"last_update"`
+	TwoFactor    bool   `json:"two_factor_enabled"`
+	Active       bool   `json:"active"`
 }
 
 type RegionCodeResponse struct {
 	Code        string `json:"code"`
 	Region      string `json:"region"`
 	Price       int    `json:"price"`
+	MinLeadDays int    `json:"min_lead_days"`
+	MaxLeadDays int    `json:"max_lead_days"`
 	FullAddress string `json:"full_address"`
 	PhoneNumber int    `json:"phone_number"`
 	AdminID     uint   `json:"admin_id"`
@@ -35,23 +39,34 @@ type DeliveryBatchResponse struct {
 	Batch         int    `json:"batch"`
 	Year          int    `json:"year"`
 	Month         int    `json:"month"`
+	ShippedAt     string `json:"shipped_at"`
+}
+
+type HolidayResponse struct {
+	ID   uint   `json:"id"`
+	Date string `json:"date"`
+	Name string `json:"name"`
 }
 
 type UserResponse struct {
-    ID           uint   `json:"id"`
-    Name         string `json:"name"`
-    Email        string `json:"email"`
-    PhoneNumber  int    `json:"phone_number"`
-    PhotoProfile string `json:"photo_profile"`
+    ID            uint   `json:"id"`
+    Name          string `json:"name"`
+    Email         string `json:"email"`
+    PhoneNumber   int    `json:"phone_number"`
+    PhotoProfile  string `json:"photo_profile"`
+    EmailVerified bool   `json:"email_verified"`
+    PhoneVerified bool   `json:"phone_verified"`
 }
 
 func UserToResponse(user uh.User) UserResponse {
     return UserResponse{
-        ID:           user.ID,
-        Name:         user.Name,
-        Email:        user.Email,
-        PhoneNumber:  user.PhoneNumber,
-        PhotoProfile: user.PhotoProfile,
+        ID:            user.ID,
+        Name:          user.Name,
+        Email:         user.Email,
+        PhoneNumber:   user.PhoneNumber,
+        PhotoProfile:  user.PhotoProfile,
+        EmailVerified: user.EmailVerifiedAt != nil,
+        PhoneVerified: user.PhoneVerifiedAt != nil,
     }
 }
 
@@ -65,6 +80,8 @@ func AdminToResponse(data admin.Admin) AdminResponse {
 		Role:         data.Role,
 		CreatedAt:    time.FormatDateToIndonesian(data.CreatedAt),
 		UpdatedAt:    time.FormatDateToIndonesian(data.UpdatedAt),
+		TwoFactor:    data.TwoFactorEnabled,
+		Active:       data.DeactivatedAt == nil,
 	}
 }
 
@@ -73,6 +90,8 @@ func CoreToResponseRegionCode(data admin.RegionCode) RegionCodeResponse {
 		Code:        data.ID,
 		Region:      data.Region,
 		Price:       data.Price,
+		MinLeadDays: data.MinLeadDays,
+		MaxLeadDays: data.MaxLeadDays,
 		FullAddress: data.FullAddress,
 		PhoneNumber: data.PhoneNumber,
 		AdminID:     data.AdminID,
@@ -80,10 +99,60 @@ func CoreToResponseRegionCode(data admin.RegionCode) RegionCodeResponse {
 }
 
 func CoreToResponseDeliveryBatch(data admin.DeliveryBatch) DeliveryBatchResponse {
+	shippedAt := ""
+	if data.ShippedAt != nil {
+		shippedAt = time.FormatDateToIndonesian(*data.ShippedAt)
+	}
+
 	return DeliveryBatchResponse{
 		DeliveryBatch: data.ID,
 		Batch:         data.Batch,
 		Year:          data.Year,
 		Month:         data.Month,
+		ShippedAt:     shippedAt,
+	}
+}
+
+func CoreToResponseHoliday(data admin.Holiday) HolidayResponse {
+	return HolidayResponse{
+		ID:   data.ID,
+		Date: time.FormatDateToIndonesian(data.Date),
+		Name: data.Name,
+	}
+}
+
+type RoleResponse struct {
+	ID          uint     `json:"id"`
+	Name        string   `json:"name"`
+	Permissions []string `json:"permissions"`
+}
+
+func CoreToResponseRole(data admin.Role) RoleResponse {
+	return RoleResponse{
+		ID:          data.ID,
+		Name:        data.Name,
+		Permissions: data.Permissions,
+	}
+}
+
+type OrphanCleanupResponse struct {
+	DryRun bool     `json:"dry_run"`
+	Total  int      `json:"total"`
+	Keys   []string `json:"keys"`
+}
+
+type TwoFactorEnrollmentResponse struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
+}
+
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+func CoreToTwoFactorEnrollmentResponse(data admin.TwoFactorEnrollment) TwoFactorEnrollmentResponse {
+	return TwoFactorEnrollmentResponse{
+		Secret:          data.Secret,
+		ProvisioningURI: data.ProvisioningURI,
 	}
 }
@@ -1,58 +1,115 @@
 package service
 
 import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
 	"errors"
+	"fmt"
+	"jastip-jakarta/app/config"
 	"jastip-jakarta/features/admin"
+	"jastip-jakarta/features/auth"
 	ud "jastip-jakarta/features/user"
 	"jastip-jakarta/utils/encrypts"
 	"jastip-jakarta/utils/middlewares"
+	"jastip-jakarta/utils/sender"
+	"jastip-jakarta/utils/storage"
+	"log"
 	"mime/multipart"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pquerna/otp"
+	"github.com/pquerna/otp/totp"
+)
+
+// objek yang lebih baru dari batas ini tidak dianggap yatim karena bisa jadi
+// upload-nya belum selesai disimpan ke database
+const orphanGracePeriod = 24 * time.Hour
+
+// nama aplikasi yang tampil di authenticator dan jumlah recovery code per admin
+const (
+	twoFactorIssuer   = "Jastip Jakarta"
+	recoveryCodeCount = 10
+	totpPeriod        = 30
 )
 
 type adminService struct {
-	adminData   admin.AdminDataInterface
-	hashService encrypts.HashInterface
-	userData    ud.UserDataInterface
+	adminData     admin.AdminDataInterface
+	hashService   encrypts.HashInterface
+	userData      ud.UserDataInterface
+	objectStorage storage.StorageInterface
+	authService   auth.AuthServiceInterface
+	sender        sender.SenderInterface
+
+	// cache permission per nama role, dikosongkan setiap kali role diubah
+	permissionMu    sync.RWMutex
+	permissionCache map[string]map[string]bool
+
+	// mencegah dua permintaan setup membuat super admin bersamaan
+	setupMu sync.Mutex
 }
 
 // dependency injection
-func New(repo admin.AdminDataInterface, hash encrypts.HashInterface, userData ud.UserDataInterface) admin.AdminServiceInterface {
+func New(repo admin.AdminDataInterface, hash encrypts.HashInterface, userData ud.UserDataInterface, objectStorage storage.StorageInterface, authService auth.AuthServiceInterface, messageSender sender.SenderInterface) admin.AdminServiceInterface {
 	return &adminService{
-		adminData:   repo,
-		hashService: hash,
-		userData:    userData,
+		adminData:       repo,
+		hashService:     hash,
+		userData:        userData,
+		objectStorage:   objectStorage,
+		authService:     authService,
+		sender:          messageSender,
+		permissionCache: make(map[string]map[string]bool),
 	}
 }
 
-// CreateSuper implements admin.AdminServiceInterface.
-func (u *adminService) CreateSuper(input admin.Admin) error {
-	if input.Password != "" {
-		hashedPass, errHash := u.hashService.HashPassword(input.Password)
-		if errHash != nil {
-			return errors.New("error hash password")
-		}
-		input.Password = hashedPass
+// SetupSuper implements admin.AdminServiceInterface.
+// Hanya bisa dipakai dengan setup token dari config dan selama belum ada super admin.
+func (u *adminService) SetupSuper(setupToken string, input admin.Admin) error {
+	if config.SETUP_TOKEN == "" {
+		return errors.New("setup admin tidak diaktifkan")
 	}
-
-	if input.Role == "" {
-		input.Role = "Super"
+	if subtle.ConstantTimeCompare([]byte(setupToken), []byte(config.SETUP_TOKEN)) != 1 {
+		return errors.New("setup token tidak valid")
+	}
+	if input.Name == "" {
+		return errors.New("nama tidak boleh kosong")
+	}
+	if input.Email == "" {
+		return errors.New("email tidak boleh kosong")
+	}
+	if input.Password == "" {
+		return errors.New("password tidak boleh kosong")
+	}
+	if input.PhoneNumber == 0 {
+		return errors.New("nomor Telephone tidak boleh kosong")
 	}
 
-	err := u.adminData.Insert(input)
-	return err
-}
+	u.setupMu.Lock()
+	defer u.setupMu.Unlock()
 
-// Create implements admin.AdminServiceInterface.
-func (u *adminService) Create(adminIdLogin int, input admin.Admin) error {
-	role, err := u.adminData.SelectById(adminIdLogin)
+	supers, err := u.adminData.SelectAdminsByRole(admin.RoleSuper)
 	if err != nil {
 		return err
 	}
+	if len(supers) > 0 {
+		return errors.New("super admin sudah ada")
+	}
 
-	if role.Role != "Super" {
-		return errors.New("anda tidak memiliki akses untuk menggunakan fitur ini")
+	hashedPass, errHash := u.hashService.HashPassword(input.Password)
+	if errHash != nil {
+		return errors.New("error hash password")
 	}
+	input.Password = hashedPass
+	input.Role = admin.RoleSuper
 
+	return u.adminData.Insert(input)
+}
+
+// Create implements admin.AdminServiceInterface.
+func (u *adminService) Create(adminIdLogin int, input admin.Admin) error {
 	if input.Name == "" {
 		return errors.New("nama tidak boleh kosong")
 	}
@@ -77,7 +134,14 @@ func (u *adminService) Create(adminIdLogin int, input admin.Admin) error {
 		input.Password = hashedPass
 	}
 
-	err = u.adminData.Insert(input)
+	if err := u.canAssignRole(adminIdLogin, input.Role); err != nil {
+		return err
+	}
+	if _, err := u.adminData.SelectRoleByName(input.Role); err != nil {
+		return err
+	}
+
+	err := u.adminData.Insert(input)
 	return err
 }
 
@@ -91,28 +155,65 @@ func (u *adminService) GetById(adminIdLogin int) (*admin.Admin, error) {
 }
 
 // Login implements admin.AdminServiceInterface.
-func (u *adminService) Login(phoneOrEmail string, password string) (data *admin.Admin, token string, err error) {
+func (u *adminService) Login(phoneOrEmail string, password string, otpCode string, client auth.ClientInfo) (data *admin.Admin, token *auth.Token, err error) {
 	// Validasi jika email atau password kosong
 	if phoneOrEmail == "" {
-		return nil, "", errors.New("email atau nomor telepon tidak boleh kosong")
+		return nil, nil, errors.New("email atau nomor telepon tidak boleh kosong")
 	}
 	if password == "" {
-		return nil, "", errors.New("password tidak boleh kosong")
+		return nil, nil, errors.New("password tidak boleh kosong")
 	}
 
-	data, err = u.adminData.Login(phoneOrEmail, password)
+	data, errLookup := u.adminData.Login(phoneOrEmail, password)
+	var adminId uint
+	if errLookup == nil {
+		adminId = data.ID
+	}
+	identifier := auth.AccountIdentifier(adminId, phoneOrEmail)
+
+	err = u.authService.CheckLogin(middlewares.AudienceAdmin, identifier, client.IPAddress)
 	if err != nil {
-		return nil, "", err
+		return nil, nil, err
 	}
 
-	isValid := u.hashService.CheckPasswordHash(data.Password, password)
-	if !isValid {
-		return nil, "", errors.New("sandi salah")
+	// bcrypt tetap dijalankan untuk akun yang tidak ada agar waktu respon tidak membocorkan akun yang terdaftar
+	passwordHash := encrypts.DummyHash
+	if errLookup == nil {
+		passwordHash = data.Password
+	}
+	if !u.hashService.CheckPasswordHash(passwordHash, password) || errLookup != nil {
+		errRecord := u.authService.RecordLoginFailure(middlewares.AudienceAdmin, identifier, client.IPAddress)
+		if errRecord != nil {
+			return nil, nil, errRecord
+		}
+		return nil, nil, auth.ErrInvalidCredentials
 	}
 
-	token, errJwt := middlewares.CreateToken(int(data.ID))
-	if errJwt != nil {
-		return nil, "", errJwt
+	if data.DeactivatedAt != nil {
+		return nil, nil, errors.New("akun admin anda telah dinonaktifkan")
+	}
+
+	if data.TwoFactorEnabled {
+		if otpCode == "" {
+			return nil, nil, admin.ErrTwoFactorRequired
+		}
+		if !u.checkTwoFactorCode(data, otpCode, true) {
+			errRecord := u.authService.RecordLoginFailure(middlewares.AudienceAdmin, identifier, client.IPAddress)
+			if errRecord != nil {
+				return nil, nil, errRecord
+			}
+			return nil, nil, admin.ErrInvalidTwoFactor
+		}
+	}
+
+	errReset := u.authService.RecordLoginSuccess(middlewares.AudienceAdmin, identifier)
+	if errReset != nil {
+		log.Println("gagal mereset percobaan login:", errReset)
+	}
+
+	token, errToken := u.authService.CreateSession(data.ID, middlewares.AudienceAdmin, client)
+	if errToken != nil {
+		return nil, nil, errToken
 	}
 
 	return data, token, err
@@ -129,16 +230,11 @@ func (u *adminService) Update(adminIdLogin int, photo *multipart.FileHeader) err
 
 // CreateRegionCode implements admin.AdminServiceInterface.
 func (u *adminService) CreateRegionCode(adminIdLogin int, input admin.RegionCode) error {
-	role, err := u.adminData.SelectById(adminIdLogin)
-	if err != nil {
-		return err
-	}
-
-	if role.Role != "Super" {
-		return errors.New("anda tidak memiliki akses untuk menggunakan fitur ini")
+	if input.MinLeadDays < 0 || input.MaxLeadDays < 0 || input.MinLeadDays > input.MaxLeadDays {
+		return errors.New("lama pengiriman minimum tidak boleh melebihi maksimum")
 	}
 
-	err = u.adminData.InsertRegionCode(input)
+	err := u.adminData.InsertRegionCode(input)
 	return err
 }
 
@@ -190,11 +286,6 @@ func (u *adminService) GetDeliveryBatch(batchID string) (*admin.DeliveryBatch, e
 
 // GettAdminsByRole implements admin.AdminServiceInterface.
 func (u *adminService) GetAdminsByRole(adminIdLogin int, role string) ([]admin.Admin, error) {
-	adminCheck, err := u.GetById(adminIdLogin)
-	if err != nil || adminCheck.Role != "Super" {
-		return nil, errors.New("anda bukan admin super")
-	}
-
 	adminRes, err := u.adminData.SelectAdminsByRole(role)
 	if err != nil {
 		return nil, err
@@ -204,11 +295,6 @@ func (u *adminService) GetAdminsByRole(adminIdLogin int, role string) ([]admin.A
 
 // GettAllAdmins implements admin.AdminServiceInterface.
 func (u *adminService) GetAllAdmins(adminIdLogin int) ([]admin.Admin, error) {
-	adminCheck, err := u.GetById(adminIdLogin)
-	if err != nil || adminCheck.Role != "Super" {
-		return nil, errors.New("anda bukan admin super")
-	}
-
 	adminRes, err := u.adminData.SelectAllAdmins()
 	if err != nil {
 		return nil, err
@@ -218,11 +304,6 @@ func (u *adminService) GetAllAdmins(adminIdLogin int) ([]admin.Admin, error) {
 
 // SearchRegionCode implements admin.AdminServiceInterface.
 func (u *adminService) SearchRegionCode(adminIdLogin int, code string) ([]admin.RegionCode, error) {
-	adminCheck, err := u.GetById(adminIdLogin)
-	if err != nil || adminCheck.Role != "Super" {
-		return nil, errors.New("anda bukan admin super")
-	}
-
 	regionCodes, err := u.adminData.SearchRegionCode(code)
 	if err != nil {
 		return nil, err
@@ -232,12 +313,11 @@ func (u *adminService) SearchRegionCode(adminIdLogin int, code string) ([]admin.
 
 // UpdateRegionCode implements admin.AdminServiceInterface.
 func (u *adminService) UpdateRegionCode(adminIdLogin int, code string, updatedRegion admin.RegionCode) error {
-	adminCheck, err := u.GetById(adminIdLogin)
-	if err != nil || adminCheck.Role != "Super" {
-		return errors.New("anda bukan admin super")
+	if updatedRegion.MinLeadDays < 0 || updatedRegion.MaxLeadDays < 0 || updatedRegion.MinLeadDays > updatedRegion.MaxLeadDays {
+		return errors.New("lama pengiriman minimum tidak boleh melebihi maksimum")
 	}
 
-	err = u.adminData.UpdateRegionCode(code, updatedRegion)
+	err := u.adminData.UpdateRegionCode(code, updatedRegion)
 	if err != nil {
 		return err
 	}
@@ -246,11 +326,6 @@ func (u *adminService) UpdateRegionCode(adminIdLogin int, code string, updatedRe
 
 // SearchUser implements admin.AdminServiceInterface.
 func (u *adminService) SearchUser(adminIdLogin int, query string) ([]ud.User, error) {
-	adminCheck, err := u.GetById(adminIdLogin)
-	if err != nil || adminCheck.Role != "Super" {
-		return nil, errors.New("anda bukan admin super")
-	}
-
 	users, err := u.userData.SelectByNameOrEmail(query)
 	if err != nil {
 		return nil, err
@@ -259,11 +334,6 @@ func (u *adminService) SearchUser(adminIdLogin int, query string) ([]ud.User, er
 }
 
 func (u *adminService) UpdateUserByName(adminIdLogin int, name string, input ud.User) error {
-	adminCheck, err := u.GetById(adminIdLogin)
-	if err != nil || adminCheck.Role != "Super" {
-		return errors.New("anda bukan admin super")
-	}
-
 	if input.Password != "" {
 		hashedPass, errHash := u.hashService.HashPassword(input.Password)
 		if errHash != nil {
@@ -282,11 +352,6 @@ func (u *adminService) UpdateUserByName(adminIdLogin int, name string, input ud.
 
 // CreateUser implements admin.AdminServiceInterface.
 func (u *adminService) CreateUser(adminIdLogin int, input ud.User) error {
-	adminCheck, err := u.GetById(adminIdLogin)
-	if err != nil || adminCheck.Role != "Super" {
-		return errors.New("anda bukan admin super")
-	}
-
 	if input.Name == "" {
 		return errors.New("nama tidak boleh kosong")
 	}
@@ -305,20 +370,766 @@ func (u *adminService) CreateUser(adminIdLogin int, input ud.User) error {
 		input.Password = hashedPass
 	}
 
-	err = u.userData.Insert(input)
+	_, err := u.userData.Insert(input)
 	return err
 }
 
 // GetAllUser implements admin.AdminServiceInterface.
 func (u *adminService) GetAllUser(adminIdLogin int) ([]ud.User, error) {
-	adminCheck, err := u.GetById(adminIdLogin)
-	if err != nil || adminCheck.Role != "Super" {
-		return nil, errors.New("anda bukan admin super")
-	}
-
 	userResponse, err := u.userData.SelectAllUser()
 	if err != nil {
 		return nil, err
 	}
 	return userResponse, nil
-}
\ No newline at end of file
+}
+
+// CreateHoliday implements admin.AdminServiceInterface.
+func (u *adminService) CreateHoliday(adminIdLogin int, input admin.Holiday) (uint, error) {
+	if input.Date.IsZero() {
+		return 0, errors.New("tanggal libur tidak boleh kosong")
+	}
+	if input.Name == "" {
+		return 0, errors.New("nama hari libur tidak boleh kosong")
+	}
+
+	return u.adminData.InsertHoliday(input)
+}
+
+// GetAllHoliday implements admin.AdminServiceInterface.
+func (u *adminService) GetAllHoliday() ([]admin.Holiday, error) {
+	return u.adminData.SelectAllHoliday()
+}
+
+// DeleteHoliday implements admin.AdminServiceInterface.
+func (u *adminService) DeleteHoliday(adminIdLogin int, holidayId uint) error {
+	return u.adminData.DeleteHoliday(holidayId)
+}
+
+// DeletePhoto implements admin.AdminServiceInterface.
+func (u *adminService) DeletePhoto(adminIdLogin int) error {
+	return u.adminData.DeletePhoto(adminIdLogin)
+}
+
+// CleanupOrphanPhotos implements admin.AdminServiceInterface.
+func (u *adminService) CleanupOrphanPhotos(adminIdLogin int, dryRun bool) ([]string, error) {
+	return u.cleanupOrphanPhotos(dryRun)
+}
+
+// StartOrphanCleanupJob implements admin.AdminServiceInterface.
+func (u *adminService) StartOrphanCleanupJob(interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			removed, err := u.cleanupOrphanPhotos(false)
+			if err != nil {
+				log.Println("error cleanup orphan photos : ", err.Error())
+				continue
+			}
+			log.Printf("cleanup orphan photos: %d objek dihapus", len(removed))
+		}
+	}()
+}
+
+// cleanupOrphanPhotos menghapus objek di storage yang tidak lagi dipakai oleh admin, user maupun foto order.
+func (u *adminService) cleanupOrphanPhotos(dryRun bool) ([]string, error) {
+	refs, err := u.adminData.SelectReferencedPhotos()
+	if err != nil {
+		return nil, err
+	}
+
+	referenced := make(map[string]bool)
+	for _, ref := range refs {
+		if key, ok := storage.KeyFromRef(u.objectStorage, ref); ok {
+			referenced[key] = true
+			referenced[storage.ThumbnailKey(key)] = true
+		}
+	}
+
+	objects, err := u.objectStorage.List(storage.UploadPrefix())
+	if err != nil {
+		return nil, err
+	}
+
+	cutoff := time.Now().Add(-orphanGracePeriod)
+	orphans := []string{}
+	for _, object := range objects {
+		if referenced[object.Key] || object.LastModified.After(cutoff) {
+			continue
+		}
+
+		if !dryRun {
+			if err := u.objectStorage.Delete(object.Key); err != nil {
+				return orphans, err
+			}
+		}
+		orphans = append(orphans, object.Key)
+	}
+	return orphans, nil
+}
+
+// SeedDefaultRoles implements admin.AdminServiceInterface.
+// Role bawaan hanya dibuat jika belum ada, sehingga perubahan permission dari Super tidak ditimpa.
+func (u *adminService) SeedDefaultRoles() error {
+	for _, role := range admin.DefaultRoles {
+		if _, err := u.adminData.SelectRoleByName(role.Name); err == nil {
+			continue
+		}
+		if err := u.adminData.InsertRole(role); err != nil {
+			return err
+		}
+	}
+	u.invalidatePermissionCache()
+	return nil
+}
+
+// HasPermission implements admin.AdminServiceInterface.
+func (u *adminService) HasPermission(adminIdLogin int, permission string) (bool, error) {
+	adminCheck, err := u.adminData.SelectById(adminIdLogin)
+	if err != nil || adminCheck == nil {
+		return false, errors.New("anda bukan admin")
+	}
+
+	if adminCheck.DeactivatedAt != nil {
+		return false, nil
+	}
+
+	permissions, err := u.rolePermissions(adminCheck.Role)
+	if err != nil {
+		return false, err
+	}
+
+	// admin dengan akses penuh (role Super atau role lama yang memiliki "*") tanpa 2FA
+	// hanya bisa memakai route tanpa permission, termasuk enrollment 2FA
+	if (adminCheck.Role == admin.RoleSuper || permissions[admin.PermissionAll]) && !adminCheck.TwoFactorEnabled {
+		return false, nil
+	}
+	if admin.TwoFactorPermissions[permission] && !adminCheck.TwoFactorEnabled {
+		return false, nil
+	}
+	return permissions[admin.PermissionAll] || permissions[permission], nil
+}
+
+// rolePermissions mengambil permission role dari cache atau database.
+func (u *adminService) rolePermissions(roleName string) (map[string]bool, error) {
+	u.permissionMu.RLock()
+	permissions, ok := u.permissionCache[roleName]
+	u.permissionMu.RUnlock()
+	if ok {
+		return permissions, nil
+	}
+
+	role, err := u.adminData.SelectRoleByName(roleName)
+	if err != nil {
+		return nil, err
+	}
+
+	permissions = make(map[string]bool, len(role.Permissions))
+	for _, permission := range role.Permissions {
+		permissions[permission] = true
+	}
+
+	u.permissionMu.Lock()
+	u.permissionCache[roleName] = permissions
+	u.permissionMu.Unlock()
+	return permissions, nil
+}
+
+func (u *adminService) invalidatePermissionCache() {
+	u.permissionMu.Lock()
+	u.permissionCache = make(map[string]map[string]bool)
+	u.permissionMu.Unlock()
+}
+
+// validatePermissions memastikan semua permission dikenal aplikasi dan tidak ada yang ganda.
+func validatePermissions(permissions []string) error {
+	known := make(map[string]bool)
+	for _, permission := range admin.Permissions {
+		known[permission] = true
+	}
+
+	seen := make(map[string]bool)
+	for _, permission := range permissions {
+		// akses penuh hanya dimiliki role Super bawaan
+		if permission == admin.PermissionAll {
+			return errors.New("permission * hanya untuk role Super")
+		}
+		if !known[permission] {
+			return errors.New("permission tidak dikenal: " + permission)
+		}
+		if seen[permission] {
+			return errors.New("permission ganda: " + permission)
+		}
+		seen[permission] = true
+	}
+	return nil
+}
+
+// isDefaultSuperRole mengecek role Super bawaan yang tidak boleh diubah agar akses penuh tidak hilang.
+func isDefaultSuperRole(name string) bool {
+	return name == admin.DefaultRoles[0].Name
+}
+
+// CreateRole implements admin.AdminServiceInterface.
+func (u *adminService) CreateRole(adminIdLogin int, input admin.Role) error {
+	input.Name = strings.TrimSpace(input.Name)
+	if input.Name == "" {
+		return errors.New("nama role tidak boleh kosong")
+	}
+	if err := validatePermissions(input.Permissions); err != nil {
+		return err
+	}
+	if err := u.canGrantPermissions(adminIdLogin, input.Permissions); err != nil {
+		return err
+	}
+
+	err := u.adminData.InsertRole(input)
+	if err != nil {
+		return err
+	}
+	u.invalidatePermissionCache()
+	return nil
+}
+
+// GetAllRoles implements admin.AdminServiceInterface.
+func (u *adminService) GetAllRoles() ([]admin.Role, error) {
+	return u.adminData.SelectAllRoles()
+}
+
+// UpdateRole implements admin.AdminServiceInterface.
+func (u *adminService) UpdateRole(adminIdLogin int, name string, permissions []string) error {
+	if isDefaultSuperRole(name) {
+		return errors.New("role Super tidak boleh diubah")
+	}
+	if err := validatePermissions(permissions); err != nil {
+		return err
+	}
+	if err := u.canGrantPermissions(adminIdLogin, permissions); err != nil {
+		return err
+	}
+
+	err := u.adminData.UpdateRolePermissions(name, permissions)
+	if err != nil {
+		return err
+	}
+	u.invalidatePermissionCache()
+	return nil
+}
+
+// canGrantPermissions mencegah admin memberikan permission yang tidak ia miliki, termasuk
+// menambahkan permission ke role miliknya sendiri.
+func (u *adminService) canGrantPermissions(adminIdLogin int, permissions []string) error {
+	actor, err := u.adminData.SelectById(adminIdLogin)
+	if err != nil {
+		return errors.New("anda bukan admin")
+	}
+	if actor.Role == admin.RoleSuper {
+		return nil
+	}
+
+	held, err := u.rolePermissions(actor.Role)
+	if err != nil {
+		return err
+	}
+	if held[admin.PermissionAll] {
+		return nil
+	}
+	for _, permission := range permissions {
+		if !held[permission] {
+			return fmt.Errorf("anda tidak bisa memberikan permission %s yang tidak anda miliki", permission)
+		}
+	}
+	return nil
+}
+
+// DeleteRole implements admin.AdminServiceInterface.
+func (u *adminService) DeleteRole(name string) error {
+	if isDefaultSuperRole(name) {
+		return errors.New("role Super tidak boleh dihapus")
+	}
+
+	admins, err := u.adminData.SelectAdminsByRole(name)
+	if err != nil {
+		return err
+	}
+	if len(admins) > 0 {
+		return errors.New("role masih dipakai oleh admin")
+	}
+
+	err = u.adminData.DeleteRole(name)
+	if err != nil {
+		return err
+	}
+	u.invalidatePermissionCache()
+	return nil
+}
+
+// RequestPasswordReset implements admin.AdminServiceInterface.
+// Akun yang tidak terdaftar tidak menghasilkan error agar keberadaan akun tidak bisa ditebak.
+func (u *adminService) RequestPasswordReset(phoneOrEmail string) error {
+	if phoneOrEmail == "" {
+		return errors.New("email atau nomor telepon tidak boleh kosong")
+	}
+
+	data, err := u.adminData.Login(phoneOrEmail, "")
+	if err != nil || data.DeactivatedAt != nil {
+		return nil
+	}
+
+	err = u.sendPasswordResetCode(data)
+	if errors.Is(err, auth.ErrCodeCooldown) || errors.Is(err, auth.ErrCodeLocked) {
+		return nil
+	}
+	return err
+}
+
+// sendPasswordResetCode membuat kode reset password lalu mengirimnya ke WhatsApp dan email admin.
+func (u *adminService) sendPasswordResetCode(data *admin.Admin) error {
+	code, err := u.authService.IssueCode(data.ID, middlewares.AudienceAdmin, auth.PurposePasswordReset)
+	if err != nil {
+		return err
+	}
+
+	return u.sender.Send(sender.Message{
+		Phone:   sender.FormatPhoneNumber(data.PhoneNumber),
+		Email:   data.Email,
+		Subject: "Kode reset password admin",
+		Body:    "Kode reset password admin Jastip Jakarta anda: " + code + ". Jangan berikan kode ini kepada siapapun.",
+	})
+}
+
+// ResetPassword implements admin.AdminServiceInterface.
+func (u *adminService) ResetPassword(phoneOrEmail, code, newPassword string) error {
+	if phoneOrEmail == "" || code == "" {
+		return errors.New("kode tidak valid")
+	}
+	if err := encrypts.ValidatePassword(newPassword); err != nil {
+		return err
+	}
+
+	data, err := u.adminData.Login(phoneOrEmail, "")
+	if err != nil || data.DeactivatedAt != nil {
+		return errors.New("kode tidak valid")
+	}
+
+	err = u.authService.VerifyCode(data.ID, middlewares.AudienceAdmin, auth.PurposePasswordReset, code)
+	if err != nil {
+		return err
+	}
+
+	hashedPass, errHash := u.hashService.HashPassword(newPassword)
+	if errHash != nil {
+		return errors.New("error hash password")
+	}
+
+	err = u.adminData.UpdatePassword(data.ID, hashedPass)
+	if err != nil {
+		return err
+	}
+
+	// semua perangkat yang login dengan password lama dikeluarkan
+	return u.authService.LogoutAll(data.ID, middlewares.AudienceAdmin)
+}
+
+// ChangePassword implements admin.AdminServiceInterface.
+// Perangkat lain yang login dengan password lama dikeluarkan, session yang sedang dipakai tetap aktif.
+func (u *adminService) ChangePassword(adminIdLogin int, sessionId, currentPassword, newPassword string) error {
+	if currentPassword == "" {
+		return errors.New("password lama tidak boleh kosong")
+	}
+	if err := encrypts.ValidatePassword(newPassword); err != nil {
+		return err
+	}
+
+	data, err := u.adminData.SelectById(adminIdLogin)
+	if err != nil {
+		return err
+	}
+	if !u.hashService.CheckPasswordHash(data.Password, currentPassword) {
+		return errors.New("password lama salah")
+	}
+	if u.hashService.CheckPasswordHash(data.Password, newPassword) {
+		return errors.New("password baru tidak boleh sama dengan password lama")
+	}
+
+	hashedPass, errHash := u.hashService.HashPassword(newPassword)
+	if errHash != nil {
+		return errors.New("error hash password")
+	}
+
+	err = u.adminData.UpdatePassword(data.ID, hashedPass)
+	if err != nil {
+		return err
+	}
+	return u.authService.LogoutOthers(data.ID, middlewares.AudienceAdmin, sessionId)
+}
+
+// SetUserVerification implements admin.AdminServiceInterface.
+// Status yang bernilai nil tidak diubah.
+func (u *adminService) SetUserVerification(name string, emailVerified, phoneVerified *bool) error {
+	if emailVerified == nil && phoneVerified == nil {
+		return errors.New("status verifikasi tidak boleh kosong")
+	}
+
+	userCheck, err := u.userData.SelectByName(name)
+	if err != nil {
+		return err
+	}
+	if userCheck == nil {
+		return errors.New("user tidak ditemukan")
+	}
+
+	now := time.Now()
+	for channel, verified := range map[string]*bool{
+		ud.VerificationEmail: emailVerified,
+		ud.VerificationPhone: phoneVerified,
+	} {
+		if verified == nil {
+			continue
+		}
+
+		var verifiedAt *time.Time
+		if *verified {
+			verifiedAt = &now
+		}
+		if err := u.userData.UpdateVerification(userCheck.ID, channel, verifiedAt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// EnrollTwoFactor implements admin.AdminServiceInterface.
+// Secret baru disimpan tetapi 2FA belum aktif sampai dikonfirmasi dengan ConfirmTwoFactor.
+func (u *adminService) EnrollTwoFactor(adminIdLogin int) (*admin.TwoFactorEnrollment, error) {
+	adminCheck, err := u.adminData.SelectById(adminIdLogin)
+	if err != nil {
+		return nil, err
+	}
+	if adminCheck.TwoFactorEnabled {
+		return nil, errors.New("2FA sudah aktif")
+	}
+
+	key, err := totp.Generate(totp.GenerateOpts{
+		Issuer:      twoFactorIssuer,
+		AccountName: adminCheck.Email,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = u.adminData.UpdateTwoFactor(adminCheck.ID, key.Secret(), false)
+	if err != nil {
+		return nil, err
+	}
+
+	return &admin.TwoFactorEnrollment{
+		Secret:          key.Secret(),
+		ProvisioningURI: key.URL(),
+	}, nil
+}
+
+// ConfirmTwoFactor implements admin.AdminServiceInterface.
+// Recovery code hanya dikembalikan sekali, yang disimpan hanya hash-nya.
+func (u *adminService) ConfirmTwoFactor(adminIdLogin int, code string) ([]string, error) {
+	adminCheck, err := u.adminData.SelectById(adminIdLogin)
+	if err != nil {
+		return nil, err
+	}
+	if adminCheck.TwoFactorEnabled {
+		return nil, errors.New("2FA sudah aktif")
+	}
+	if adminCheck.TwoFactorSecret == "" {
+		return nil, errors.New("lakukan enrollment 2FA terlebih dahulu")
+	}
+	if !u.checkTwoFactorCode(adminCheck, code, false) {
+		return nil, admin.ErrInvalidTwoFactor
+	}
+
+	recoveryCodes, err := u.replaceRecoveryCodes(adminCheck.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = u.adminData.UpdateTwoFactor(adminCheck.ID, adminCheck.TwoFactorSecret, true)
+	if err != nil {
+		return nil, err
+	}
+	return recoveryCodes, nil
+}
+
+// RegenerateRecoveryCodes implements admin.AdminServiceInterface.
+func (u *adminService) RegenerateRecoveryCodes(adminIdLogin int, code string) ([]string, error) {
+	adminCheck, err := u.adminData.SelectById(adminIdLogin)
+	if err != nil {
+		return nil, err
+	}
+	if !adminCheck.TwoFactorEnabled {
+		return nil, errors.New("2FA belum aktif")
+	}
+	if !u.checkTwoFactorCode(adminCheck, code, false) {
+		return nil, admin.ErrInvalidTwoFactor
+	}
+
+	return u.replaceRecoveryCodes(adminCheck.ID)
+}
+
+// DisableTwoFactor implements admin.AdminServiceInterface.
+func (u *adminService) DisableTwoFactor(adminIdLogin int, code string) error {
+	adminCheck, err := u.adminData.SelectById(adminIdLogin)
+	if err != nil {
+		return err
+	}
+	if adminCheck.Role == admin.RoleSuper {
+		return errors.New("2FA wajib untuk role Super")
+	}
+	if !adminCheck.TwoFactorEnabled {
+		return errors.New("2FA belum aktif")
+	}
+	if !u.checkTwoFactorCode(adminCheck, code, true) {
+		return admin.ErrInvalidTwoFactor
+	}
+
+	return u.clearTwoFactor(adminCheck.ID)
+}
+
+// ResetTwoFactor implements admin.AdminServiceInterface.
+// Dipakai super admin untuk admin lain yang kehilangan authenticator. Semua session admin tersebut ikut dicabut.
+func (u *adminService) ResetTwoFactor(adminIdLogin int, adminId int) error {
+	actor, err := u.adminData.SelectById(adminIdLogin)
+	if err != nil {
+		return err
+	}
+	if actor.Role != admin.RoleSuper {
+		return errors.New("hanya super admin yang bisa mereset 2FA")
+	}
+	if adminIdLogin == adminId {
+		return errors.New("tidak bisa mereset 2FA akun sendiri")
+	}
+
+	target, err := u.adminData.SelectById(adminId)
+	if err != nil {
+		return err
+	}
+
+	err = u.clearTwoFactor(target.ID)
+	if err != nil {
+		return err
+	}
+	return u.authService.LogoutAll(target.ID, middlewares.AudienceAdmin)
+}
+
+// checkTwoFactorCode menerima kode TOTP, atau recovery code jika allowRecovery bernilai true.
+// Kode TOTP yang sudah pernah diterima tidak bisa dipakai lagi.
+func (u *adminService) checkTwoFactorCode(adminCheck *admin.Admin, code string, allowRecovery bool) bool {
+	code = strings.TrimSpace(code)
+	if step, ok := totpStep(adminCheck.TwoFactorSecret, code, time.Now()); ok {
+		return u.adminData.UseTwoFactorStep(adminCheck.ID, step) == nil
+	}
+	if !allowRecovery {
+		return false
+	}
+	return u.adminData.UseRecoveryCode(adminCheck.ID, hashRecoveryCode(code)) == nil
+}
+
+// totpStep mencari time step yang menghasilkan kode, dengan toleransi satu step sebelum
+// dan sesudah waktu sekarang seperti totp.Validate.
+func totpStep(secret, code string, now time.Time) (int64, bool) {
+	if secret == "" || code == "" {
+		return 0, false
+	}
+	opts := totp.ValidateOpts{
+		Period:    totpPeriod,
+		Digits:    otp.DigitsSix,
+		Algorithm: otp.AlgorithmSHA1,
+	}
+	for _, skew := range []int64{-1, 0, 1} {
+		at := now.Add(time.Duration(skew*totpPeriod) * time.Second)
+		expected, err := totp.GenerateCodeCustom(secret, at, opts)
+		if err != nil {
+			return 0, false
+		}
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return at.Unix() / totpPeriod, true
+		}
+	}
+	return 0, false
+}
+
+func (u *adminService) replaceRecoveryCodes(adminId uint) ([]string, error) {
+	var recoveryCodes, codeHashes []string
+	for i := 0; i < recoveryCodeCount; i++ {
+		random := make([]byte, 5)
+		if _, err := rand.Read(random); err != nil {
+			return nil, err
+		}
+		code := fmt.Sprintf("%s-%s", hex.EncodeToString(random[:2]), hex.EncodeToString(random[2:]))
+		recoveryCodes = append(recoveryCodes, code)
+		codeHashes = append(codeHashes, hashRecoveryCode(code))
+	}
+
+	err := u.adminData.ReplaceRecoveryCodes(adminId, codeHashes)
+	if err != nil {
+		return nil, err
+	}
+	return recoveryCodes, nil
+}
+
+func (u *adminService) clearTwoFactor(adminId uint) error {
+	err := u.adminData.UpdateTwoFactor(adminId, "", false)
+	if err != nil {
+		return err
+	}
+	return u.adminData.ReplaceRecoveryCodes(adminId, nil)
+}
+
+func hashRecoveryCode(code string) string {
+	sum := sha256.Sum256([]byte(strings.ToLower(code)))
+	return hex.EncodeToString(sum[:])
+}
+
+// DeactivateAdmin implements admin.AdminServiceInterface.
+func (u *adminService) DeactivateAdmin(adminIdLogin int, adminId int) error {
+	target, err := u.manageableAdmin(adminIdLogin, adminId)
+	if err != nil {
+		return err
+	}
+	if target.DeactivatedAt != nil {
+		return errors.New("admin sudah dinonaktifkan")
+	}
+
+	now := time.Now()
+	err = u.adminData.UpdateDeactivatedAt(target.ID, &now)
+	if err != nil {
+		return err
+	}
+	return u.authService.LogoutAll(target.ID, middlewares.AudienceAdmin)
+}
+
+// ActivateAdmin implements admin.AdminServiceInterface.
+func (u *adminService) ActivateAdmin(adminIdLogin int, adminId int) error {
+	target, err := u.manageableAdmin(adminIdLogin, adminId)
+	if err != nil {
+		return err
+	}
+	if target.DeactivatedAt == nil {
+		return errors.New("admin masih aktif")
+	}
+
+	return u.adminData.UpdateDeactivatedAt(target.ID, nil)
+}
+
+// ChangeAdminRole implements admin.AdminServiceInterface.
+func (u *adminService) ChangeAdminRole(adminIdLogin int, adminId int, role string) error {
+	if role == "" {
+		return errors.New("role tidak boleh kosong")
+	}
+
+	target, err := u.manageableAdmin(adminIdLogin, adminId)
+	if err != nil {
+		return err
+	}
+	if err := u.canAssignRole(adminIdLogin, role); err != nil {
+		return err
+	}
+
+	if _, err := u.adminData.SelectRoleByName(role); err != nil {
+		return err
+	}
+	return u.adminData.UpdateRole(target.ID, role)
+}
+
+// ForcePasswordReset implements admin.AdminServiceInterface.
+// Password lama langsung tidak berlaku, semua session dicabut dan kode reset dikirim ke admin tersebut.
+func (u *adminService) ForcePasswordReset(adminIdLogin int, adminId int) error {
+	target, err := u.manageableAdmin(adminIdLogin, adminId)
+	if err != nil {
+		return err
+	}
+
+	random := make([]byte, 32)
+	if _, err := rand.Read(random); err != nil {
+		return err
+	}
+	hashedPass, errHash := u.hashService.HashPassword(hex.EncodeToString(random))
+	if errHash != nil {
+		return errors.New("error hash password")
+	}
+
+	err = u.adminData.UpdatePassword(target.ID, hashedPass)
+	if err != nil {
+		return err
+	}
+	err = u.authService.LogoutAll(target.ID, middlewares.AudienceAdmin)
+	if err != nil {
+		return err
+	}
+
+	if target.DeactivatedAt != nil {
+		return nil
+	}
+	return u.sendPasswordResetCode(target)
+}
+
+// DeleteAdmin implements admin.AdminServiceInterface.
+func (u *adminService) DeleteAdmin(adminIdLogin int, adminId int) error {
+	target, err := u.manageableAdmin(adminIdLogin, adminId)
+	if err != nil {
+		return err
+	}
+
+	regions, err := u.adminData.SelectAllRegionCode()
+	if err != nil {
+		return err
+	}
+	for _, region := range regions {
+		if region.AdminID == target.ID {
+			return errors.New("admin masih menjadi penanggung jawab wilayah " + region.ID + ", pindahkan wilayah terlebih dahulu")
+		}
+	}
+
+	err = u.adminData.Delete(target.ID)
+	if err != nil {
+		return err
+	}
+	return u.authService.LogoutAll(target.ID, middlewares.AudienceAdmin)
+}
+
+// canAssignRole memastikan role Super hanya bisa diberikan oleh super admin, baik saat
+// membuat admin baru maupun saat mengganti role.
+func (u *adminService) canAssignRole(adminIdLogin int, role string) error {
+	if role != admin.RoleSuper {
+		return nil
+	}
+	actor, err := u.adminData.SelectById(adminIdLogin)
+	if err != nil {
+		return err
+	}
+	if actor.Role != admin.RoleSuper {
+		return errors.New("hanya super admin yang bisa memberikan role Super")
+	}
+	return nil
+}
+
+// manageableAdmin mengambil admin yang akan dikelola. Admin tidak bisa mengelola akunnya sendiri dan
+// akun super admin hanya bisa dikelola oleh super admin lain, sehingga selalu tersisa minimal satu super admin.
+func (u *adminService) manageableAdmin(adminIdLogin int, adminId int) (*admin.Admin, error) {
+	if adminIdLogin == adminId {
+		return nil, errors.New("tidak bisa mengelola akun sendiri")
+	}
+
+	actor, err := u.adminData.SelectById(adminIdLogin)
+	if err != nil {
+		return nil, errors.New("anda bukan admin")
+	}
+
+	target, err := u.adminData.SelectById(adminId)
+	if err != nil {
+		return nil, errors.New("admin tidak ditemukan")
+	}
+	if target.Role == admin.RoleSuper && actor.Role != admin.RoleSuper {
+		return nil, errors.New("hanya super admin yang bisa mengelola akun super admin")
+	}
+	return target, nil
+}
@@ -0,0 +1,58 @@
+package data
+
+import (
+	"jastip-jakarta/features/apikey"
+	"strings"
+	"time"
+)
+
+type APIKey struct {
+	ID         uint   `gorm:"primaryKey"`
+	Name       string `gorm:"type:varchar(100)"`
+	Prefix     string `gorm:"type:varchar(16);uniqueIndex"`
+	KeyHash    string `gorm:"type:varchar(64)"`
+	Audience   string `gorm:"type:varchar(20);index:idx_apikey_subject"`
+	SubjectID  uint   `gorm:"index:idx_apikey_subject"`
+	Scopes     string `gorm:"type:varchar(255)"`
+	CreatedBy  uint
+	ExpiresAt  time.Time
+	LastUsedAt *time.Time
+	RevokedAt  *time.Time
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+func APIKeyToModel(input apikey.APIKey) APIKey {
+	return APIKey{
+		Name:      input.Name,
+		Prefix:    input.Prefix,
+		KeyHash:   input.KeyHash,
+		Audience:  input.Audience,
+		SubjectID: input.SubjectID,
+		Scopes:    strings.Join(input.Scopes, ","),
+		CreatedBy: input.CreatedBy,
+		ExpiresAt: input.ExpiresAt,
+	}
+}
+
+func (k APIKey) ModelToAPIKey() apikey.APIKey {
+	var scopes []string
+	if k.Scopes != "" {
+		scopes = strings.Split(k.Scopes, ",")
+	}
+	return apikey.APIKey{
+		ID:         k.ID,
+		Name:       k.Name,
+		Prefix:     k.Prefix,
+		KeyHash:    k.KeyHash,
+		Audience:   k.Audience,
+		SubjectID:  k.SubjectID,
+		Scopes:     scopes,
+		CreatedBy:  k.CreatedBy,
+		ExpiresAt:  k.ExpiresAt,
+		LastUsedAt: k.LastUsedAt,
+		RevokedAt:  k.RevokedAt,
+		CreatedAt:  k.CreatedAt,
+		UpdatedAt:  k.UpdatedAt,
+	}
+}
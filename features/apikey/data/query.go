@@ -0,0 +1,89 @@
+package data
+
+import (
+	"errors"
+	"jastip-jakarta/features/apikey"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type apiKeyQuery struct {
+	db *gorm.DB
+}
+
+func New(db *gorm.DB) apikey.APIKeyDataInterface {
+	return &apiKeyQuery{
+		db: db,
+	}
+}
+
+// Insert implements apikey.APIKeyDataInterface.
+func (a *apiKeyQuery) Insert(input apikey.APIKey) (*apikey.APIKey, error) {
+	dataGorm := APIKeyToModel(input)
+	tx := a.db.Create(&dataGorm)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+	result := dataGorm.ModelToAPIKey()
+	return &result, nil
+}
+
+// SelectAll implements apikey.APIKeyDataInterface.
+func (a *apiKeyQuery) SelectAll() ([]apikey.APIKey, error) {
+	var keysGorm []APIKey
+	tx := a.db.Order("created_at desc").Find(&keysGorm)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	var result []apikey.APIKey
+	for _, k := range keysGorm {
+		result = append(result, k.ModelToAPIKey())
+	}
+	return result, nil
+}
+
+// SelectById implements apikey.APIKeyDataInterface.
+func (a *apiKeyQuery) SelectById(keyId uint) (*apikey.APIKey, error) {
+	return a.selectWhere("id = ?", keyId)
+}
+
+// SelectByPrefix implements apikey.APIKeyDataInterface.
+func (a *apiKeyQuery) SelectByPrefix(prefix string) (*apikey.APIKey, error) {
+	return a.selectWhere("prefix = ?", prefix)
+}
+
+func (a *apiKeyQuery) selectWhere(query string, args ...interface{}) (*apikey.APIKey, error) {
+	var keyGorm APIKey
+	err := a.db.Where(query, args...).First(&keyGorm).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("API key tidak ditemukan")
+		}
+		return nil, err
+	}
+	result := keyGorm.ModelToAPIKey()
+	return &result, nil
+}
+
+// UpdateLastUsed implements apikey.APIKeyDataInterface.
+func (a *apiKeyQuery) UpdateLastUsed(keyId uint, usedAt time.Time) error {
+	tx := a.db.Model(&APIKey{}).Where("id = ?", keyId).Update("last_used_at", usedAt)
+	if tx.Error != nil {
+		return tx.Error
+	}
+	return nil
+}
+
+// Revoke implements apikey.APIKeyDataInterface.
+func (a *apiKeyQuery) Revoke(keyId uint) error {
+	tx := a.db.Model(&APIKey{}).Where("id = ? AND revoked_at IS NULL", keyId).Update("revoked_at", time.Now())
+	if tx.Error != nil {
+		return tx.Error
+	}
+	if tx.RowsAffected == 0 {
+		return errors.New("API key tidak ditemukan atau sudah dicabut")
+	}
+	return nil
+}
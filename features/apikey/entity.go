@@ -0,0 +1,60 @@
+package apikey
+
+import (
+	"errors"
+	"jastip-jakarta/utils/middlewares"
+	"time"
+)
+
+// APIKey dipakai klien non-interaktif (integrasi toko, skrip ekspor) untuk mengakses API
+// atas nama satu user atau admin. Key hanya ditampilkan sekali saat dibuat dan disimpan dalam bentuk hash.
+type APIKey struct {
+	ID         uint
+	Name       string
+	Prefix     string
+	KeyHash    string
+	Audience   string
+	SubjectID  uint
+	Scopes     []string
+	CreatedBy  uint
+	ExpiresAt  time.Time
+	LastUsedAt *time.Time
+	RevokedAt  *time.Time
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// scope yang bisa diberikan ke API key
+const (
+	ScopeOrdersRead   = "orders:read"
+	ScopeOrdersCreate = "orders:create"
+	ScopeExport       = "export"
+)
+
+// ScopeAudiences berisi scope yang dikenal beserta audience yang boleh memakainya.
+var ScopeAudiences = map[string][]string{
+	ScopeOrdersRead:   {middlewares.AudienceUser, middlewares.AudienceAdmin},
+	ScopeOrdersCreate: {middlewares.AudienceUser},
+	ScopeExport:       {middlewares.AudienceAdmin},
+}
+
+// ErrInvalidAPIKey dipakai untuk semua key yang tidak dikenal, kedaluwarsa atau sudah dicabut.
+var ErrInvalidAPIKey = errors.New("API key tidak valid")
+
+// interface untuk Data Layer
+type APIKeyDataInterface interface {
+	Insert(input APIKey) (*APIKey, error)
+	SelectAll() ([]APIKey, error)
+	SelectById(keyId uint) (*APIKey, error)
+	SelectByPrefix(prefix string) (*APIKey, error)
+	UpdateLastUsed(keyId uint, usedAt time.Time) error
+	Revoke(keyId uint) error
+}
+
+// interface untuk Service Layer
+type APIKeyServiceInterface interface {
+	Create(adminIdLogin int, input APIKey, validDays int) (*APIKey, string, error)
+	GetAll(adminIdLogin int) ([]APIKey, error)
+	Revoke(adminIdLogin int, keyId uint) error
+	Authenticate(rawKey string) (subjectId uint, audience string, scopes []string, err error)
+}
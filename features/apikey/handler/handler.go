@@ -0,0 +1,84 @@
+package handler
+
+import (
+	"jastip-jakarta/features/apikey"
+	"net/http"
+	"strconv"
+
+	"jastip-jakarta/utils/middlewares"
+	"jastip-jakarta/utils/responses"
+
+	"github.com/labstack/echo/v4"
+)
+
+type APIKeyHandler struct {
+	apiKeyService apikey.APIKeyServiceInterface
+}
+
+func New(service apikey.APIKeyServiceInterface) *APIKeyHandler {
+	return &APIKeyHandler{
+		apiKeyService: service,
+	}
+}
+
+func (handler *APIKeyHandler) CreateAPIKey(c echo.Context) error {
+	adminIdLogin := middlewares.ExtractTokenAdminId(c)
+	if adminIdLogin == 0 {
+		return c.JSON(http.StatusUnauthorized, responses.WebResponse("Silahkan login terlebih dahulu", nil))
+	}
+
+	reqData := APIKeyRequest{}
+	errBind := c.Bind(&reqData)
+	if errBind != nil {
+		return c.JSON(http.StatusBadRequest, responses.WebResponse("error bind data, data not valid", nil))
+	}
+
+	result, rawKey, err := handler.apiKeyService.Create(adminIdLogin, RequestToAPIKey(reqData), reqData.ValidDays)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, responses.WebResponse(err.Error(), nil))
+	}
+	middlewares.SetAuditEntity(c, strconv.Itoa(int(result.ID)))
+
+	return c.JSON(http.StatusOK, responses.WebResponse("Berhasil membuat API key, simpan key ini karena tidak akan ditampilkan lagi", CreatedAPIKeyResponse{
+		APIKeyResponse: CoreToAPIKeyResponse(*result),
+		Key:            rawKey,
+	}))
+}
+
+func (handler *APIKeyHandler) GetAllAPIKey(c echo.Context) error {
+	adminIdLogin := middlewares.ExtractTokenAdminId(c)
+	if adminIdLogin == 0 {
+		return c.JSON(http.StatusUnauthorized, responses.WebResponse("Silahkan login terlebih dahulu", nil))
+	}
+
+	result, err := handler.apiKeyService.GetAll(adminIdLogin)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, responses.WebResponse(err.Error(), nil))
+	}
+
+	var keyResult []APIKeyResponse
+	for _, v := range result {
+		keyResult = append(keyResult, CoreToAPIKeyResponse(v))
+	}
+
+	return c.JSON(http.StatusOK, responses.WebResponse("Berhasil mendapatkan daftar API key", keyResult))
+}
+
+func (handler *APIKeyHandler) RevokeAPIKey(c echo.Context) error {
+	adminIdLogin := middlewares.ExtractTokenAdminId(c)
+	if adminIdLogin == 0 {
+		return c.JSON(http.StatusUnauthorized, responses.WebResponse("Silahkan login terlebih dahulu", nil))
+	}
+
+	keyId, errConv := strconv.Atoi(c.Param("id"))
+	if errConv != nil {
+		return c.JSON(http.StatusBadRequest, responses.WebResponse("id tidak valid", nil))
+	}
+
+	err := handler.apiKeyService.Revoke(adminIdLogin, uint(keyId))
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, responses.WebResponse(err.Error(), nil))
+	}
+
+	return c.JSON(http.StatusOK, responses.WebResponse("Berhasil mencabut API key", nil))
+}
@@ -0,0 +1,20 @@
+package handler
+
+import "jastip-jakarta/features/apikey"
+
+type APIKeyRequest struct {
+	Name      string   `json:"name" form:"name"`
+	Audience  string   `json:"audience" form:"audience"`
+	SubjectID uint     `json:"subject_id" form:"subject_id"`
+	Scopes    []string `json:"scopes" form:"scopes"`
+	ValidDays int      `json:"valid_days" form:"valid_days"`
+}
+
+func RequestToAPIKey(input APIKeyRequest) apikey.APIKey {
+	return apikey.APIKey{
+		Name:      input.Name,
+		Audience:  input.Audience,
+		SubjectID: input.SubjectID,
+		Scopes:    input.Scopes,
+	}
+}
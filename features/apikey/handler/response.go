@@ -0,0 +1,42 @@
+package handler
+
+import (
+	"jastip-jakarta/features/apikey"
+	"time"
+)
+
+type APIKeyResponse struct {
+	ID         uint       `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Audience   string     `json:"audience"`
+	SubjectID  uint       `json:"subject_id"`
+	Scopes     []string   `json:"scopes"`
+	CreatedBy  uint       `json:"created_by"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// CreatedAPIKeyResponse menyertakan key mentah yang hanya ditampilkan sekali.
+type CreatedAPIKeyResponse struct {
+	APIKeyResponse
+	Key string `json:"key"`
+}
+
+func CoreToAPIKeyResponse(data apikey.APIKey) APIKeyResponse {
+	return APIKeyResponse{
+		ID:         data.ID,
+		Name:       data.Name,
+		Prefix:     data.Prefix,
+		Audience:   data.Audience,
+		SubjectID:  data.SubjectID,
+		Scopes:     data.Scopes,
+		CreatedBy:  data.CreatedBy,
+		ExpiresAt:  data.ExpiresAt,
+		LastUsedAt: data.LastUsedAt,
+		RevokedAt:  data.RevokedAt,
+		CreatedAt:  data.CreatedAt,
+	}
+}
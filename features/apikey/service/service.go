@@ -0,0 +1,195 @@
+package service
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"jastip-jakarta/features/admin"
+	"jastip-jakarta/features/apikey"
+	"jastip-jakarta/features/user"
+	"jastip-jakarta/utils/middlewares"
+	"strings"
+	"time"
+)
+
+// awalan key agar mudah dikenali saat tidak sengaja ter-commit atau tercatat di log
+const keyPrefix = "jk"
+
+const (
+	defaultValidDays = 90
+	maxValidDays     = 365
+)
+
+// last_used_at tidak ditulis ulang di setiap request agar key yang sering dipakai tidak membebani database
+const lastUsedResolution = time.Minute
+
+type apiKeyService struct {
+	apiKeyData apikey.APIKeyDataInterface
+	adminData  admin.AdminDataInterface
+	userData   user.UserDataInterface
+}
+
+// dependency injection
+func New(repo apikey.APIKeyDataInterface, adminData admin.AdminDataInterface, userData user.UserDataInterface) apikey.APIKeyServiceInterface {
+	return &apiKeyService{
+		apiKeyData: repo,
+		adminData:  adminData,
+		userData:   userData,
+	}
+}
+
+// Create implements apikey.APIKeyServiceInterface.
+// Key mentah hanya dikembalikan di sini, yang disimpan hanya hash-nya.
+func (a *apiKeyService) Create(adminIdLogin int, input apikey.APIKey, validDays int) (*apikey.APIKey, string, error) {
+	input.Name = strings.TrimSpace(input.Name)
+	if input.Name == "" {
+		return nil, "", errors.New("nama API key tidak boleh kosong")
+	}
+	scopes, err := validateScopes(input.Audience, input.Scopes)
+	if err != nil {
+		return nil, "", err
+	}
+	if err := a.checkSubject(input.Audience, input.SubjectID); err != nil {
+		return nil, "", err
+	}
+
+	if validDays == 0 {
+		validDays = defaultValidDays
+	}
+	if validDays < 0 || validDays > maxValidDays {
+		return nil, "", errors.New("masa berlaku API key harus antara 1 sampai 365 hari")
+	}
+
+	prefix, err := randomToken(6)
+	if err != nil {
+		return nil, "", err
+	}
+	secret, err := randomToken(32)
+	if err != nil {
+		return nil, "", err
+	}
+	rawKey := keyPrefix + "_" + prefix + "_" + secret
+
+	key, err := a.apiKeyData.Insert(apikey.APIKey{
+		Name:      input.Name,
+		Prefix:    prefix,
+		KeyHash:   hashKey(rawKey),
+		Audience:  input.Audience,
+		SubjectID: input.SubjectID,
+		Scopes:    scopes,
+		CreatedBy: uint(adminIdLogin),
+		ExpiresAt: time.Now().AddDate(0, 0, validDays),
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	return key, rawKey, nil
+}
+
+// GetAll implements apikey.APIKeyServiceInterface.
+func (a *apiKeyService) GetAll(adminIdLogin int) ([]apikey.APIKey, error) {
+	return a.apiKeyData.SelectAll()
+}
+
+// Revoke implements apikey.APIKeyServiceInterface.
+func (a *apiKeyService) Revoke(adminIdLogin int, keyId uint) error {
+	return a.apiKeyData.Revoke(keyId)
+}
+
+// Authenticate implements apikey.APIKeyServiceInterface.
+// Semua kegagalan dikembalikan sebagai ErrInvalidAPIKey agar keberadaan prefix tidak bisa ditebak.
+func (a *apiKeyService) Authenticate(rawKey string) (uint, string, []string, error) {
+	parts := strings.Split(rawKey, "_")
+	if len(parts) != 3 || parts[0] != keyPrefix || parts[1] == "" || parts[2] == "" {
+		return 0, "", nil, apikey.ErrInvalidAPIKey
+	}
+
+	key, err := a.apiKeyData.SelectByPrefix(parts[1])
+	if err != nil {
+		return 0, "", nil, apikey.ErrInvalidAPIKey
+	}
+	if subtle.ConstantTimeCompare([]byte(hashKey(rawKey)), []byte(key.KeyHash)) != 1 {
+		return 0, "", nil, apikey.ErrInvalidAPIKey
+	}
+
+	now := time.Now()
+	if key.RevokedAt != nil || now.After(key.ExpiresAt) {
+		return 0, "", nil, apikey.ErrInvalidAPIKey
+	}
+	if err := a.checkSubject(key.Audience, key.SubjectID); err != nil {
+		return 0, "", nil, apikey.ErrInvalidAPIKey
+	}
+
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) >= lastUsedResolution {
+		a.apiKeyData.UpdateLastUsed(key.ID, now)
+	}
+	return key.SubjectID, key.Audience, key.Scopes, nil
+}
+
+// checkSubject memastikan akun pemilik key masih ada dan aktif.
+func (a *apiKeyService) checkSubject(audience string, subjectId uint) error {
+	switch audience {
+	case middlewares.AudienceUser:
+		if _, err := a.userData.SelectById(int(subjectId)); err != nil {
+			return errors.New("user tidak ditemukan")
+		}
+	case middlewares.AudienceAdmin:
+		target, err := a.adminData.SelectById(int(subjectId))
+		if err != nil {
+			return errors.New("admin tidak ditemukan")
+		}
+		if target.DeactivatedAt != nil {
+			return errors.New("admin sudah dinonaktifkan")
+		}
+	default:
+		return errors.New("audience API key harus user atau admin")
+	}
+	return nil
+}
+
+// validateScopes menolak scope yang tidak dikenal atau tidak berlaku untuk audience key.
+func validateScopes(audience string, scopes []string) ([]string, error) {
+	var result []string
+	seen := make(map[string]bool)
+	for _, scope := range scopes {
+		scope = strings.TrimSpace(scope)
+		audiences, known := apikey.ScopeAudiences[scope]
+		if !known {
+			return nil, errors.New("scope tidak dikenal: " + scope)
+		}
+		allowed := false
+		for _, aud := range audiences {
+			if aud == audience {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return nil, errors.New("scope " + scope + " tidak berlaku untuk " + audience)
+		}
+		if !seen[scope] {
+			seen[scope] = true
+			result = append(result, scope)
+		}
+	}
+	if len(result) == 0 {
+		return nil, errors.New("API key harus memiliki minimal satu scope")
+	}
+	return result, nil
+}
+
+func randomToken(size int) (string, error) {
+	random := make([]byte, size)
+	if _, err := rand.Read(random); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(random), nil
+}
+
+// hashKey menyimpan API key dalam bentuk hash agar kebocoran database tidak membocorkan key.
+func hashKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,81 @@
+package data
+
+import (
+	"jastip-jakarta/features/audit"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// AuditLog sengaja tidak memakai gorm.Model agar tidak ada soft delete.
+type AuditLog struct {
+	ID         uint   `gorm:"primaryKey"`
+	AdminID    uint   `gorm:"index"`
+	Method     string `gorm:"type:varchar(10)"`
+	Path       string `gorm:"type:varchar(255);index"`
+	EntityType string `gorm:"type:varchar(50);index:idx_audit_entity"`
+	EntityID   string `gorm:"type:varchar(100);index:idx_audit_entity"`
+	Changes    string `gorm:"type:text"`
+	StatusCode int
+	IPAddress  string `gorm:"type:varchar(64)"`
+	UserAgent  string
+	CreatedAt  time.Time `gorm:"index"`
+}
+
+func AuditLogToModel(input audit.AuditLog) AuditLog {
+	return AuditLog{
+		AdminID:    input.AdminID,
+		Method:     input.Method,
+		Path:       input.Path,
+		EntityType: input.EntityType,
+		EntityID:   input.EntityID,
+		Changes:    input.Changes,
+		StatusCode: input.StatusCode,
+		IPAddress:  input.IPAddress,
+		UserAgent:  input.UserAgent,
+	}
+}
+
+func (l AuditLog) ModelToAuditLog() audit.AuditLog {
+	return audit.AuditLog{
+		ID:         l.ID,
+		AdminID:    l.AdminID,
+		Method:     l.Method,
+		Path:       l.Path,
+		EntityType: l.EntityType,
+		EntityID:   l.EntityID,
+		Changes:    l.Changes,
+		StatusCode: l.StatusCode,
+		IPAddress:  l.IPAddress,
+		UserAgent:  l.UserAgent,
+		CreatedAt:  l.CreatedAt,
+	}
+}
+
+// appendOnlyTriggers menolak UPDATE dan DELETE pada tabel audit_logs langsung di database,
+// sehingga log tetap utuh walaupun ada query di luar data layer ini.
+var appendOnlyTriggers = map[string]string{
+	"audit_logs_no_update": "BEFORE UPDATE",
+	"audit_logs_no_delete": "BEFORE DELETE",
+}
+
+// EnforceAppendOnly membuat trigger append-only jika belum ada. Dipanggil setelah AutoMigrate.
+func EnforceAppendOnly(db *gorm.DB) error {
+	for name, timing := range appendOnlyTriggers {
+		var count int64
+		err := db.Raw("SELECT COUNT(*) FROM information_schema.TRIGGERS WHERE TRIGGER_SCHEMA = DATABASE() AND TRIGGER_NAME = ?", name).Scan(&count).Error
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			continue
+		}
+
+		err = db.Exec("CREATE TRIGGER " + name + " " + timing + " ON audit_logs FOR EACH ROW " +
+			"SIGNAL SQLSTATE '45000' SET MESSAGE_TEXT = 'audit log tidak bisa diubah atau dihapus'").Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
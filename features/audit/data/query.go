@@ -0,0 +1,62 @@
+package data
+
+import (
+	"jastip-jakarta/features/audit"
+
+	"gorm.io/gorm"
+)
+
+type auditQuery struct {
+	db *gorm.DB
+}
+
+func New(db *gorm.DB) audit.AuditDataInterface {
+	return &auditQuery{
+		db: db,
+	}
+}
+
+// Insert implements audit.AuditDataInterface.
+func (a *auditQuery) Insert(input audit.AuditLog) error {
+	dataGorm := AuditLogToModel(input)
+	tx := a.db.Create(&dataGorm)
+	if tx.Error != nil {
+		return tx.Error
+	}
+	return nil
+}
+
+// Search implements audit.AuditDataInterface.
+func (a *auditQuery) Search(filter audit.AuditFilter) ([]audit.AuditLog, error) {
+	query := a.db.Model(&AuditLog{})
+	if filter.AdminID != 0 {
+		query = query.Where("admin_id = ?", filter.AdminID)
+	}
+	if filter.EntityType != "" {
+		query = query.Where("entity_type = ?", filter.EntityType)
+	}
+	if filter.EntityID != "" {
+		query = query.Where("entity_id = ?", filter.EntityID)
+	}
+	if filter.Path != "" {
+		query = query.Where("path LIKE ?", "%"+filter.Path+"%")
+	}
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("created_at < ?", *filter.To)
+	}
+
+	var logsGorm []AuditLog
+	tx := query.Order("created_at desc, id desc").Limit(filter.Limit).Offset(filter.Offset).Find(&logsGorm)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	var result []audit.AuditLog
+	for _, l := range logsGorm {
+		result = append(result, l.ModelToAuditLog())
+	}
+	return result, nil
+}
@@ -0,0 +1,64 @@
+package audit
+
+import (
+	"jastip-jakarta/utils/middlewares"
+	"time"
+)
+
+// jenis entitas yang dicatat di audit log
+const (
+	EntityAdmin         = "admin"
+	EntityUser          = "user"
+	EntityRole          = "role"
+	EntityBatch         = "batch"
+	EntityHoliday       = "holiday"
+	EntityRegion        = "region"
+	EntityOrder         = "order"
+	EntityBatchOrders   = "batch_orders"
+	EntityPhotoOrder    = "photo_order"
+	EntityAPIKey        = "apikey"
+	EntityLoginThrottle = "login_throttle"
+	EntityStorage       = "storage"
+)
+
+// AuditLog mencatat satu perubahan data oleh admin. Log hanya bisa ditambah, tidak bisa diubah atau dihapus.
+type AuditLog struct {
+	ID         uint
+	AdminID    uint
+	Method     string
+	Path       string
+	EntityType string
+	EntityID   string
+	// Changes berisi JSON {"field": {"before": ..., "after": ...}} untuk field yang berubah
+	Changes    string
+	StatusCode int
+	IPAddress  string
+	UserAgent  string
+	CreatedAt  time.Time
+}
+
+// AuditFilter berisi kriteria pencarian audit log, field kosong tidak dipakai.
+type AuditFilter struct {
+	AdminID    uint
+	EntityType string
+	EntityID   string
+	Path       string
+	From       *time.Time
+	To         *time.Time
+	Limit      int
+	Offset     int
+}
+
+// interface untuk Data Layer. Sengaja tidak ada method untuk mengubah atau menghapus log,
+// dan tabelnya dijaga trigger database (lihat data.EnforceAppendOnly).
+type AuditDataInterface interface {
+	Insert(input AuditLog) error
+	Search(filter AuditFilter) ([]AuditLog, error)
+}
+
+// interface untuk Service Layer
+type AuditServiceInterface interface {
+	RecordAudit(entry middlewares.AuditEntry) error
+	Search(adminIdLogin int, filter AuditFilter) ([]AuditLog, error)
+	Export(adminIdLogin int, filter AuditFilter) ([]AuditLog, error)
+}
@@ -0,0 +1,100 @@
+package handler
+
+import (
+	"encoding/csv"
+	"fmt"
+	"jastip-jakarta/features/audit"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"jastip-jakarta/utils/middlewares"
+	"jastip-jakarta/utils/responses"
+
+	"github.com/labstack/echo/v4"
+)
+
+type AuditHandler struct {
+	auditService audit.AuditServiceInterface
+}
+
+func New(service audit.AuditServiceInterface) *AuditHandler {
+	return &AuditHandler{
+		auditService: service,
+	}
+}
+
+func (handler *AuditHandler) SearchAuditLog(c echo.Context) error {
+	adminIdLogin := middlewares.ExtractTokenAdminId(c)
+	if adminIdLogin == 0 {
+		return c.JSON(http.StatusUnauthorized, responses.WebResponse("Silahkan login terlebih dahulu", nil))
+	}
+
+	filter, errFilter := QueryToAuditFilter(c)
+	if errFilter != nil {
+		return c.JSON(http.StatusBadRequest, responses.WebResponse(errFilter.Error(), nil))
+	}
+
+	result, err := handler.auditService.Search(adminIdLogin, filter)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, responses.WebResponse(err.Error(), nil))
+	}
+
+	var logResult []AuditLogResponse
+	for _, v := range result {
+		logResult = append(logResult, CoreToAuditLogResponse(v))
+	}
+
+	return c.JSON(http.StatusOK, responses.WebResponse("Berhasil mendapatkan audit log", logResult))
+}
+
+func (handler *AuditHandler) ExportAuditLog(c echo.Context) error {
+	adminIdLogin := middlewares.ExtractTokenAdminId(c)
+	if adminIdLogin == 0 {
+		return c.JSON(http.StatusUnauthorized, responses.WebResponse("Silahkan login terlebih dahulu", nil))
+	}
+
+	filter, errFilter := QueryToAuditFilter(c)
+	if errFilter != nil {
+		return c.JSON(http.StatusBadRequest, responses.WebResponse(errFilter.Error(), nil))
+	}
+
+	result, err := handler.auditService.Export(adminIdLogin, filter)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, responses.WebResponse(err.Error(), nil))
+	}
+
+	fileName := "audit_log_" + time.Now().Format("20060102150405") + ".csv"
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%s", fileName))
+	c.Response().Header().Set(echo.HeaderContentType, "text/csv")
+	c.Response().WriteHeader(http.StatusOK)
+
+	writer := csv.NewWriter(c.Response())
+	writer.Write([]string{"ID", "Waktu", "Admin ID", "Method", "Path", "Entitas", "ID Entitas", "Perubahan", "Status", "IP", "User Agent"})
+	for _, v := range result {
+		writer.Write([]string{
+			strconv.Itoa(int(v.ID)),
+			v.CreatedAt.Format(time.RFC3339),
+			strconv.Itoa(int(v.AdminID)),
+			v.Method,
+			v.Path,
+			v.EntityType,
+			csvCell(v.EntityID),
+			csvCell(v.Changes),
+			strconv.Itoa(v.StatusCode),
+			csvCell(v.IPAddress),
+			csvCell(v.UserAgent),
+		})
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// csvCell mencegah nilai dari request (misalnya user agent) dibaca sebagai formula oleh aplikasi spreadsheet.
+func csvCell(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
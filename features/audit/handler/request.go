@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"errors"
+	"jastip-jakarta/features/audit"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// QueryToAuditFilter membaca filter dari query parameter. Tanggal memakai format dd/mm/yyyy
+// dan tanggal "to" ikut disertakan.
+func QueryToAuditFilter(c echo.Context) (audit.AuditFilter, error) {
+	filter := audit.AuditFilter{
+		EntityType: c.QueryParam("entity_type"),
+		EntityID:   c.QueryParam("entity_id"),
+		Path:       c.QueryParam("path"),
+	}
+
+	if adminId := c.QueryParam("admin_id"); adminId != "" {
+		id, err := strconv.Atoi(adminId)
+		if err != nil {
+			return filter, errors.New("admin_id tidak valid")
+		}
+		filter.AdminID = uint(id)
+	}
+	if from := c.QueryParam("from"); from != "" {
+		date, err := time.ParseInLocation("02/01/2006", from, time.Local)
+		if err != nil {
+			return filter, errors.New("format tanggal from harus dd/mm/yyyy")
+		}
+		filter.From = &date
+	}
+	if to := c.QueryParam("to"); to != "" {
+		date, err := time.ParseInLocation("02/01/2006", to, time.Local)
+		if err != nil {
+			return filter, errors.New("format tanggal to harus dd/mm/yyyy")
+		}
+		date = date.AddDate(0, 0, 1)
+		filter.To = &date
+	}
+	if limit := c.QueryParam("limit"); limit != "" {
+		value, err := strconv.Atoi(limit)
+		if err != nil {
+			return filter, errors.New("limit tidak valid")
+		}
+		filter.Limit = value
+	}
+	if offset := c.QueryParam("offset"); offset != "" {
+		value, err := strconv.Atoi(offset)
+		if err != nil {
+			return filter, errors.New("offset tidak valid")
+		}
+		filter.Offset = value
+	}
+	return filter, nil
+}
@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"encoding/json"
+	"jastip-jakarta/features/audit"
+	"time"
+)
+
+type AuditLogResponse struct {
+	ID         uint            `json:"id"`
+	AdminID    uint            `json:"admin_id"`
+	Method     string          `json:"method"`
+	Path       string          `json:"path"`
+	EntityType string          `json:"entity_type"`
+	EntityID   string          `json:"entity_id"`
+	Changes    json.RawMessage `json:"changes"`
+	StatusCode int             `json:"status_code"`
+	IPAddress  string          `json:"ip_address"`
+	UserAgent  string          `json:"user_agent"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
+
+func CoreToAuditLogResponse(data audit.AuditLog) AuditLogResponse {
+	var changes json.RawMessage
+	if data.Changes != "" {
+		changes = json.RawMessage(data.Changes)
+	}
+	return AuditLogResponse{
+		ID:         data.ID,
+		AdminID:    data.AdminID,
+		Method:     data.Method,
+		Path:       data.Path,
+		EntityType: data.EntityType,
+		EntityID:   data.EntityID,
+		Changes:    changes,
+		StatusCode: data.StatusCode,
+		IPAddress:  data.IPAddress,
+		UserAgent:  data.UserAgent,
+		CreatedAt:  data.CreatedAt,
+	}
+}
@@ -0,0 +1,186 @@
+package service
+
+import (
+	"encoding/json"
+	"jastip-jakarta/features/audit"
+	"jastip-jakarta/utils/middlewares"
+	"reflect"
+	"strings"
+)
+
+const (
+	defaultSearchLimit = 50
+	maxSearchLimit     = 500
+	maxExportRows      = 10000
+)
+
+// nama field yang nilainya tidak boleh ikut tersimpan di audit log
+var sensitiveFields = []string{"password", "secret", "hash"}
+
+const redacted = "[disembunyikan]"
+
+type auditService struct {
+	auditData audit.AuditDataInterface
+}
+
+// dependency injection
+func New(repo audit.AuditDataInterface) audit.AuditServiceInterface {
+	return &auditService{
+		auditData: repo,
+	}
+}
+
+// fieldChange adalah nilai satu field sebelum dan sesudah perubahan.
+type fieldChange struct {
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+// RecordAudit implements audit.AuditServiceInterface.
+func (a *auditService) RecordAudit(entry middlewares.AuditEntry) error {
+	changes := ""
+	if entry.Before != nil || entry.After != nil {
+		before, err := toJSONValue(entry.Before)
+		if err != nil {
+			return err
+		}
+		after, err := toJSONValue(entry.After)
+		if err != nil {
+			return err
+		}
+
+		diff := make(map[string]fieldChange)
+		diffValues("", before, after, diff)
+		if len(diff) > 0 {
+			encoded, err := json.Marshal(diff)
+			if err != nil {
+				return err
+			}
+			changes = string(encoded)
+		}
+	}
+
+	return a.auditData.Insert(audit.AuditLog{
+		AdminID:    entry.AdminID,
+		Method:     entry.Method,
+		Path:       entry.Path,
+		EntityType: entry.EntityType,
+		EntityID:   entry.EntityID,
+		Changes:    changes,
+		StatusCode: entry.StatusCode,
+		IPAddress:  entry.IPAddress,
+		UserAgent:  entry.UserAgent,
+	})
+}
+
+// Search implements audit.AuditServiceInterface.
+func (a *auditService) Search(adminIdLogin int, filter audit.AuditFilter) ([]audit.AuditLog, error) {
+	if filter.Limit <= 0 {
+		filter.Limit = defaultSearchLimit
+	}
+	if filter.Limit > maxSearchLimit {
+		filter.Limit = maxSearchLimit
+	}
+	if filter.Offset < 0 {
+		filter.Offset = 0
+	}
+	return a.auditData.Search(filter)
+}
+
+// Export implements audit.AuditServiceInterface.
+func (a *auditService) Export(adminIdLogin int, filter audit.AuditFilter) ([]audit.AuditLog, error) {
+	filter.Limit = maxExportRows
+	filter.Offset = 0
+	return a.auditData.Search(filter)
+}
+
+// toJSONValue mengubah snapshot menjadi bentuk JSON umum agar bisa dibandingkan per field.
+func toJSONValue(value interface{}) (interface{}, error) {
+	if value == nil {
+		return nil, nil
+	}
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var result interface{}
+	if err := json.Unmarshal(encoded, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// diffValues menelusuri object secara rekursif dan mencatat field yang berbeda dengan
+// nama berbentuk path, misalnya "OrderDetails.Status".
+func diffValues(path string, before, after interface{}, diff map[string]fieldChange) {
+	beforeMap, beforeIsMap := before.(map[string]interface{})
+	afterMap, afterIsMap := after.(map[string]interface{})
+	if (beforeIsMap || before == nil) && (afterIsMap || after == nil) && (beforeIsMap || afterIsMap) {
+		keys := make(map[string]bool)
+		for key := range beforeMap {
+			keys[key] = true
+		}
+		for key := range afterMap {
+			keys[key] = true
+		}
+		for key := range keys {
+			childPath := key
+			if path != "" {
+				childPath = path + "." + key
+			}
+			diffValues(childPath, beforeMap[key], afterMap[key], diff)
+		}
+		return
+	}
+
+	if reflect.DeepEqual(before, after) {
+		return
+	}
+	if isSensitive(path) {
+		diff[path] = fieldChange{Before: redactLeaf(before), After: redactLeaf(after)}
+		return
+	}
+	diff[path] = fieldChange{Before: redactValue(before), After: redactValue(after)}
+}
+
+func isSensitive(path string) bool {
+	name := strings.ToLower(path[strings.LastIndex(path, ".")+1:])
+	for _, field := range sensitiveFields {
+		if strings.Contains(name, field) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactLeaf tetap menunjukkan bahwa field sensitif berubah tanpa menyimpan nilainya.
+func redactLeaf(value interface{}) interface{} {
+	if value == nil || value == "" {
+		return value
+	}
+	return redacted
+}
+
+// redactValue menyembunyikan field sensitif di dalam array yang dibandingkan secara utuh.
+func redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(v))
+		for key, child := range v {
+			if isSensitive(key) {
+				result[key] = redactLeaf(child)
+			} else {
+				result[key] = redactValue(child)
+			}
+		}
+		return result
+	case []interface{}:
+		result := make([]interface{}, len(v))
+		for i, child := range v {
+			result[i] = redactValue(child)
+		}
+		return result
+	default:
+		return value
+	}
+}
@@ -0,0 +1,111 @@
+package data
+
+import (
+	"jastip-jakarta/features/auth"
+	"time"
+)
+
+type Session struct {
+	ID               string `gorm:"type:varchar(64);primaryKey"`
+	SubjectID        uint   `gorm:"index:idx_session_subject"`
+	Audience         string `gorm:"type:varchar(20);index:idx_session_subject"`
+	RefreshTokenHash string `gorm:"type:varchar(64)"`
+	UserAgent        string
+	IPAddress        string `gorm:"type:varchar(64)"`
+	ExpiresAt        time.Time
+	LastUsedAt       time.Time
+	RevokedAt        *time.Time
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+type OneTimeCode struct {
+	ID        uint   `gorm:"primaryKey"`
+	SubjectID uint   `gorm:"index:idx_code_subject"`
+	Audience  string `gorm:"type:varchar(20);index:idx_code_subject"`
+	Purpose   string `gorm:"type:varchar(50);index:idx_code_subject"`
+	CodeHash  string `gorm:"type:varchar(64)"`
+	Attempts  int
+	ExpiresAt time.Time
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
+
+type LoginThrottle struct {
+	ID           uint   `gorm:"primaryKey"`
+	Kind         string `gorm:"type:varchar(20);uniqueIndex:idx_throttle_key"`
+	Audience     string `gorm:"type:varchar(20);uniqueIndex:idx_throttle_key"`
+	Identifier   string `gorm:"type:varchar(255);uniqueIndex:idx_throttle_key"`
+	Failures     int
+	LockedUntil  *time.Time `gorm:"index"`
+	LastFailedAt time.Time
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+func SessionToModel(input auth.Session) Session {
+	return Session{
+		ID:               input.ID,
+		SubjectID:        input.SubjectID,
+		Audience:         input.Audience,
+		RefreshTokenHash: input.RefreshTokenHash,
+		UserAgent:        input.UserAgent,
+		IPAddress:        input.IPAddress,
+		ExpiresAt:        input.ExpiresAt,
+		LastUsedAt:       input.LastUsedAt,
+	}
+}
+
+func (s Session) ModelToSession() auth.Session {
+	return auth.Session{
+		ID:               s.ID,
+		SubjectID:        s.SubjectID,
+		Audience:         s.Audience,
+		RefreshTokenHash: s.RefreshTokenHash,
+		UserAgent:        s.UserAgent,
+		IPAddress:        s.IPAddress,
+		ExpiresAt:        s.ExpiresAt,
+		LastUsedAt:       s.LastUsedAt,
+		RevokedAt:        s.RevokedAt,
+		CreatedAt:        s.CreatedAt,
+		UpdatedAt:        s.UpdatedAt,
+	}
+}
+
+func OneTimeCodeToModel(input auth.OneTimeCode) OneTimeCode {
+	return OneTimeCode{
+		SubjectID: input.SubjectID,
+		Audience:  input.Audience,
+		Purpose:   input.Purpose,
+		CodeHash:  input.CodeHash,
+		ExpiresAt: input.ExpiresAt,
+	}
+}
+
+func (c OneTimeCode) ModelToOneTimeCode() auth.OneTimeCode {
+	return auth.OneTimeCode{
+		ID:        c.ID,
+		SubjectID: c.SubjectID,
+		Audience:  c.Audience,
+		Purpose:   c.Purpose,
+		CodeHash:  c.CodeHash,
+		Attempts:  c.Attempts,
+		ExpiresAt: c.ExpiresAt,
+		UsedAt:    c.UsedAt,
+		CreatedAt: c.CreatedAt,
+	}
+}
+
+func (t LoginThrottle) ModelToLoginThrottle() auth.LoginThrottle {
+	return auth.LoginThrottle{
+		ID:           t.ID,
+		Kind:         t.Kind,
+		Audience:     t.Audience,
+		Identifier:   t.Identifier,
+		Failures:     t.Failures,
+		LockedUntil:  t.LockedUntil,
+		LastFailedAt: t.LastFailedAt,
+		CreatedAt:    t.CreatedAt,
+		UpdatedAt:    t.UpdatedAt,
+	}
+}
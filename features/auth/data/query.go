@@ -0,0 +1,222 @@
+package data
+
+import (
+	"errors"
+	"jastip-jakarta/features/auth"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type authQuery struct {
+	db *gorm.DB
+}
+
+func New(db *gorm.DB) auth.AuthDataInterface {
+	return &authQuery{
+		db: db,
+	}
+}
+
+// InsertSession implements auth.AuthDataInterface.
+func (a *authQuery) InsertSession(input auth.Session) error {
+	dataGorm := SessionToModel(input)
+	tx := a.db.Create(&dataGorm)
+	if tx.Error != nil {
+		return tx.Error
+	}
+	return nil
+}
+
+// SelectSessionById implements auth.AuthDataInterface.
+func (a *authQuery) SelectSessionById(sessionId string) (*auth.Session, error) {
+	var sessionGorm Session
+	err := a.db.Where("id = ?", sessionId).First(&sessionGorm).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("session tidak ditemukan")
+		}
+		return nil, err
+	}
+	session := sessionGorm.ModelToSession()
+	return &session, nil
+}
+
+// RotateRefreshToken implements auth.AuthDataInterface.
+// Refresh token hanya diganti jika hash lama masih cocok, sehingga token yang sudah dipakai tidak bisa dipakai lagi.
+func (a *authQuery) RotateRefreshToken(sessionId, oldHash, newHash string, expiresAt time.Time) error {
+	tx := a.db.Model(&Session{}).
+		Where("id = ? AND refresh_token_hash = ? AND revoked_at IS NULL", sessionId, oldHash).
+		Updates(map[string]interface{}{
+			"refresh_token_hash": newHash,
+			"expires_at":         expiresAt,
+			"last_used_at":       time.Now(),
+		})
+	if tx.Error != nil {
+		return tx.Error
+	}
+	if tx.RowsAffected == 0 {
+		return errors.New("refresh token tidak valid")
+	}
+	return nil
+}
+
+// RevokeSession implements auth.AuthDataInterface.
+func (a *authQuery) RevokeSession(sessionId string) error {
+	tx := a.db.Model(&Session{}).
+		Where("id = ? AND revoked_at IS NULL", sessionId).
+		Update("revoked_at", time.Now())
+	if tx.Error != nil {
+		return tx.Error
+	}
+	return nil
+}
+
+// RevokeAllSessions implements auth.AuthDataInterface.
+func (a *authQuery) RevokeAllSessions(subjectId uint, audience string) error {
+	tx := a.db.Model(&Session{}).
+		Where("subject_id = ? AND audience = ? AND revoked_at IS NULL", subjectId, audience).
+		Update("revoked_at", time.Now())
+	if tx.Error != nil {
+		return tx.Error
+	}
+	return nil
+}
+
+// RevokeOtherSessions implements auth.AuthDataInterface.
+func (a *authQuery) RevokeOtherSessions(subjectId uint, audience, keepSessionId string) error {
+	tx := a.db.Model(&Session{}).
+		Where("subject_id = ? AND audience = ? AND id <> ? AND revoked_at IS NULL", subjectId, audience, keepSessionId).
+		Update("revoked_at", time.Now())
+	if tx.Error != nil {
+		return tx.Error
+	}
+	return nil
+}
+
+// InsertCode implements auth.AuthDataInterface.
+// Kode lama dengan kegunaan yang sama ditandai terpakai sehingga hanya kode terbaru yang berlaku.
+func (a *authQuery) InsertCode(input auth.OneTimeCode) error {
+	return a.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&OneTimeCode{}).
+			Where("subject_id = ? AND audience = ? AND purpose = ? AND used_at IS NULL", input.SubjectID, input.Audience, input.Purpose).
+			Update("used_at", time.Now()).Error
+		if err != nil {
+			return err
+		}
+
+		dataGorm := OneTimeCodeToModel(input)
+		return tx.Create(&dataGorm).Error
+	})
+}
+
+// SelectLatestCode implements auth.AuthDataInterface.
+func (a *authQuery) SelectLatestCode(subjectId uint, audience, purpose string) (*auth.OneTimeCode, error) {
+	var codeGorm OneTimeCode
+	err := a.db.Where("subject_id = ? AND audience = ? AND purpose = ?", subjectId, audience, purpose).
+		Order("created_at DESC, id DESC").
+		First(&codeGorm).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("kode tidak ditemukan")
+		}
+		return nil, err
+	}
+	code := codeGorm.ModelToOneTimeCode()
+	return &code, nil
+}
+
+// IncrementCodeAttempts implements auth.AuthDataInterface.
+func (a *authQuery) IncrementCodeAttempts(codeId uint) error {
+	return a.db.Model(&OneTimeCode{}).Where("id = ?", codeId).
+		Update("attempts", gorm.Expr("attempts + 1")).Error
+}
+
+// MarkCodeUsed implements auth.AuthDataInterface.
+func (a *authQuery) MarkCodeUsed(codeId uint) error {
+	tx := a.db.Model(&OneTimeCode{}).Where("id = ? AND used_at IS NULL", codeId).Update("used_at", time.Now())
+	if tx.Error != nil {
+		return tx.Error
+	}
+	if tx.RowsAffected == 0 {
+		return errors.New("kode sudah dipakai")
+	}
+	return nil
+}
+
+// SelectThrottle implements auth.AuthDataInterface.
+// Mengembalikan nil tanpa error jika belum pernah ada kegagalan login.
+func (a *authQuery) SelectThrottle(kind, audience, identifier string) (*auth.LoginThrottle, error) {
+	var throttleGorm LoginThrottle
+	err := a.db.Where("kind = ? AND audience = ? AND identifier = ?", kind, audience, identifier).First(&throttleGorm).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	throttle := throttleGorm.ModelToLoginThrottle()
+	return &throttle, nil
+}
+
+// IncrementThrottle implements auth.AuthDataInterface.
+// Penambahan dilakukan di database agar percobaan bersamaan tetap terhitung. Kegagalan yang lebih lama
+// dari windowStart tidak dihitung lagi.
+func (a *authQuery) IncrementThrottle(kind, audience, identifier string, windowStart time.Time) (*auth.LoginThrottle, error) {
+	now := time.Now()
+	err := a.db.Clauses(clause.OnConflict{
+		DoUpdates: []clause.Assignment{
+			{Column: clause.Column{Name: "failures"}, Value: gorm.Expr("IF(last_failed_at < ?, 1, failures + 1)", windowStart)},
+			{Column: clause.Column{Name: "last_failed_at"}, Value: now},
+			{Column: clause.Column{Name: "updated_at"}, Value: now},
+		},
+	}).Create(&LoginThrottle{
+		Kind:         kind,
+		Audience:     audience,
+		Identifier:   identifier,
+		Failures:     1,
+		LastFailedAt: now,
+	}).Error
+	if err != nil {
+		return nil, err
+	}
+	return a.SelectThrottle(kind, audience, identifier)
+}
+
+// LockThrottle implements auth.AuthDataInterface.
+func (a *authQuery) LockThrottle(throttleId uint, lockedUntil time.Time) error {
+	return a.db.Model(&LoginThrottle{}).Where("id = ?", throttleId).Update("locked_until", lockedUntil).Error
+}
+
+// DeleteThrottle implements auth.AuthDataInterface.
+func (a *authQuery) DeleteThrottle(kind, audience, identifier string) error {
+	return a.db.Where("kind = ? AND audience = ? AND identifier = ?", kind, audience, identifier).Delete(&LoginThrottle{}).Error
+}
+
+// SelectLockedThrottles implements auth.AuthDataInterface.
+func (a *authQuery) SelectLockedThrottles() ([]auth.LoginThrottle, error) {
+	var throttlesGorm []LoginThrottle
+	err := a.db.Where("locked_until > ?", time.Now()).Order("locked_until DESC").Find(&throttlesGorm).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var throttles []auth.LoginThrottle
+	for _, throttle := range throttlesGorm {
+		throttles = append(throttles, throttle.ModelToLoginThrottle())
+	}
+	return throttles, nil
+}
+
+// DeleteThrottleById implements auth.AuthDataInterface.
+func (a *authQuery) DeleteThrottleById(throttleId uint) error {
+	tx := a.db.Where("id = ?", throttleId).Delete(&LoginThrottle{})
+	if tx.Error != nil {
+		return tx.Error
+	}
+	if tx.RowsAffected == 0 {
+		return errors.New("data tidak ditemukan")
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Session mewakili satu perangkat yang sedang login. Access token membawa ID session
+// sehingga token bisa dicabut sebelum masa berlakunya habis.
+type Session struct {
+	ID               string
+	SubjectID        uint
+	Audience         string
+	RefreshTokenHash string
+	UserAgent        string
+	IPAddress        string
+	ExpiresAt        time.Time
+	LastUsedAt       time.Time
+	RevokedAt        *time.Time
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+type Token struct {
+	AccessToken  string
+	RefreshToken string
+	ExpiresAt    time.Time
+}
+
+// OneTimeCode adalah kode OTP yang dikirim ke user atau admin. Kode disimpan dalam bentuk hash.
+type OneTimeCode struct {
+	ID        uint
+	SubjectID uint
+	Audience  string
+	Purpose   string
+	CodeHash  string
+	Attempts  int
+	ExpiresAt time.Time
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
+
+// LoginThrottle mencatat kegagalan login untuk satu akun atau satu alamat IP.
+type LoginThrottle struct {
+	ID           uint
+	Kind         string
+	Audience     string
+	Identifier   string
+	Failures     int
+	LockedUntil  *time.Time
+	LastFailedAt time.Time
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// jenis pembatasan login
+const (
+	ThrottleAccount = "account"
+	ThrottleIP      = "ip"
+	ThrottleOTP     = "otp"
+)
+
+// kegunaan kode OTP
+const (
+	PurposePasswordReset = "password_reset"
+	PurposeVerifyEmail   = "verify_email"
+	PurposeVerifyPhone   = "verify_phone"
+)
+
+// AccountIdentifier menentukan identifier pembatasan login untuk satu akun. Jika akun
+// ditemukan, ID akun yang dipakai agar semua penulisan email atau nomor telepon milik akun
+// yang sama berbagi satu hitungan. Input mentah hanya dipakai untuk akun yang tidak ada.
+func AccountIdentifier(subjectId uint, input string) string {
+	if subjectId != 0 {
+		return fmt.Sprintf("id:%d", subjectId)
+	}
+	return input
+}
+
+// ErrCodeCooldown dikembalikan jika kode baru diminta sebelum jeda kirim ulang selesai.
+var ErrCodeCooldown = errors.New("tunggu sebentar sebelum meminta kode baru")
+
+// ErrCodeLocked dikembalikan selama akun dikunci karena terlalu banyak salah memasukkan kode OTP.
+var ErrCodeLocked = errors.New("terlalu banyak percobaan kode, silahkan coba lagi nanti")
+
+// ErrLoginLocked dikembalikan selama akun atau IP sedang dikunci karena terlalu banyak gagal login.
+var ErrLoginLocked = errors.New("terlalu banyak percobaan login, silahkan coba lagi nanti")
+
+// ErrInvalidCredentials dipakai untuk semua kegagalan login agar keberadaan akun tidak bisa ditebak.
+var ErrInvalidCredentials = errors.New("email/nomor telepon atau password salah")
+
+// ClientInfo berisi informasi perangkat yang melakukan login.
+type ClientInfo struct {
+	UserAgent string
+	IPAddress string
+}
+
+// interface untuk Data Layer
+type AuthDataInterface interface {
+	InsertSession(input Session) error
+	SelectSessionById(sessionId string) (*Session, error)
+	RotateRefreshToken(sessionId, oldHash, newHash string, expiresAt time.Time) error
+	RevokeSession(sessionId string) error
+	RevokeAllSessions(subjectId uint, audience string) error
+	RevokeOtherSessions(subjectId uint, audience, keepSessionId string) error
+	InsertCode(input OneTimeCode) error
+	SelectLatestCode(subjectId uint, audience, purpose string) (*OneTimeCode, error)
+	IncrementCodeAttempts(codeId uint) error
+	MarkCodeUsed(codeId uint) error
+	SelectThrottle(kind, audience, identifier string) (*LoginThrottle, error)
+	IncrementThrottle(kind, audience, identifier string, windowStart time.Time) (*LoginThrottle, error)
+	LockThrottle(throttleId uint, lockedUntil time.Time) error
+	DeleteThrottle(kind, audience, identifier string) error
+	SelectLockedThrottles() ([]LoginThrottle, error)
+	DeleteThrottleById(throttleId uint) error
+}
+
+// interface untuk Service Layer
+type AuthServiceInterface interface {
+	CreateSession(subjectId uint, audience string, client ClientInfo) (*Token, error)
+	Refresh(refreshToken, audience string) (*Token, error)
+	Logout(sessionId string) error
+	LogoutAll(subjectId uint, audience string) error
+	LogoutOthers(subjectId uint, audience, keepSessionId string) error
+	IsSessionActive(sessionId string) (bool, error)
+	IssueCode(subjectId uint, audience, purpose string) (string, error)
+	VerifyCode(subjectId uint, audience, purpose, code string) error
+	CheckLogin(audience, identifier, ipAddress string) error
+	RecordLoginFailure(audience, identifier, ipAddress string) error
+	RecordLoginSuccess(audience, identifier string) error
+	GetLockedLogins() ([]LoginThrottle, error)
+	UnlockLogin(throttleId uint) error
+}
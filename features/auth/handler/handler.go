@@ -0,0 +1,112 @@
+package handler
+
+import (
+	"jastip-jakarta/features/auth"
+	"net/http"
+	"strconv"
+
+	"jastip-jakarta/utils/middlewares"
+	"jastip-jakarta/utils/responses"
+
+	"github.com/labstack/echo/v4"
+)
+
+type AuthHandler struct {
+	authService auth.AuthServiceInterface
+}
+
+func New(service auth.AuthServiceInterface) *AuthHandler {
+	return &AuthHandler{
+		authService: service,
+	}
+}
+
+func (handler *AuthHandler) RefreshUser(c echo.Context) error {
+	return handler.refresh(c, middlewares.AudienceUser)
+}
+
+func (handler *AuthHandler) RefreshAdmin(c echo.Context) error {
+	return handler.refresh(c, middlewares.AudienceAdmin)
+}
+
+func (handler *AuthHandler) refresh(c echo.Context, audience string) error {
+	reqData := RefreshRequest{}
+	errBind := c.Bind(&reqData)
+	if errBind != nil {
+		return c.JSON(http.StatusBadRequest, responses.WebResponse("error bind data, data not valid", nil))
+	}
+
+	token, err := handler.authService.Refresh(reqData.RefreshToken, audience)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, responses.WebResponse(err.Error(), nil))
+	}
+
+	return c.JSON(http.StatusOK, responses.WebResponse("Berhasil memperbarui token", CoreToTokenResponse(*token)))
+}
+
+func (handler *AuthHandler) Logout(c echo.Context) error {
+	sessionId := middlewares.ExtractTokenSessionId(c)
+	if sessionId == "" {
+		return c.JSON(http.StatusUnauthorized, responses.WebResponse("Silahkan login terlebih dahulu", nil))
+	}
+
+	err := handler.authService.Logout(sessionId)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, responses.WebResponse(err.Error(), nil))
+	}
+
+	return c.JSON(http.StatusOK, responses.WebResponse("Logout berhasil", nil))
+}
+
+func (handler *AuthHandler) LogoutAllUser(c echo.Context) error {
+	userIdLogin := middlewares.ExtractTokenUserId(c)
+	if userIdLogin == 0 {
+		return c.JSON(http.StatusUnauthorized, responses.WebResponse("Silahkan login terlebih dahulu", nil))
+	}
+	return handler.logoutAll(c, uint(userIdLogin), middlewares.AudienceUser)
+}
+
+func (handler *AuthHandler) LogoutAllAdmin(c echo.Context) error {
+	adminIdLogin := middlewares.ExtractTokenAdminId(c)
+	if adminIdLogin == 0 {
+		return c.JSON(http.StatusUnauthorized, responses.WebResponse("Silahkan login terlebih dahulu", nil))
+	}
+	return handler.logoutAll(c, uint(adminIdLogin), middlewares.AudienceAdmin)
+}
+
+func (handler *AuthHandler) logoutAll(c echo.Context, subjectId uint, audience string) error {
+	err := handler.authService.LogoutAll(subjectId, audience)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, responses.WebResponse(err.Error(), nil))
+	}
+
+	return c.JSON(http.StatusOK, responses.WebResponse("Berhasil logout dari semua perangkat", nil))
+}
+
+func (handler *AuthHandler) GetLockedLogins(c echo.Context) error {
+	result, err := handler.authService.GetLockedLogins()
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, responses.WebResponse(err.Error(), nil))
+	}
+
+	var throttleResult []LoginThrottleResponse
+	for _, v := range result {
+		throttleResult = append(throttleResult, CoreToLoginThrottleResponse(v))
+	}
+
+	return c.JSON(http.StatusOK, responses.WebResponse("Berhasil mendapatkan daftar login yang terkunci", throttleResult))
+}
+
+func (handler *AuthHandler) UnlockLogin(c echo.Context) error {
+	throttleId, errConv := strconv.Atoi(c.Param("id"))
+	if errConv != nil {
+		return c.JSON(http.StatusBadRequest, responses.WebResponse("id tidak valid", nil))
+	}
+
+	err := handler.authService.UnlockLogin(uint(throttleId))
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, responses.WebResponse(err.Error(), nil))
+	}
+
+	return c.JSON(http.StatusOK, responses.WebResponse("Berhasil membuka kunci login", nil))
+}
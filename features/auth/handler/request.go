@@ -0,0 +1,5 @@
+package handler
+
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token" form:"refresh_token"`
+}
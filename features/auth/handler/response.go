@@ -0,0 +1,42 @@
+package handler
+
+import (
+	"jastip-jakarta/features/auth"
+	"time"
+)
+
+type TokenResponse struct {
+	Token        string    `json:"token"`
+	RefreshToken string    `json:"refresh_token"`
+	ExpiresAt    time.Time `json:"expires_at"`
+}
+
+func CoreToTokenResponse(data auth.Token) TokenResponse {
+	return TokenResponse{
+		Token:        data.AccessToken,
+		RefreshToken: data.RefreshToken,
+		ExpiresAt:    data.ExpiresAt,
+	}
+}
+
+type LoginThrottleResponse struct {
+	ID           uint       `json:"id"`
+	Type         string     `json:"type"`
+	Audience     string     `json:"audience"`
+	Identifier   string     `json:"identifier"`
+	Failures     int        `json:"failures"`
+	LockedUntil  *time.Time `json:"locked_until"`
+	LastFailedAt time.Time  `json:"last_failed_at"`
+}
+
+func CoreToLoginThrottleResponse(data auth.LoginThrottle) LoginThrottleResponse {
+	return LoginThrottleResponse{
+		ID:           data.ID,
+		Type:         data.Kind,
+		Audience:     data.Audience,
+		Identifier:   data.Identifier,
+		Failures:     data.Failures,
+		LockedUntil:  data.LockedUntil,
+		LastFailedAt: data.LastFailedAt,
+	}
+}
@@ -0,0 +1,355 @@
+package service
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"jastip-jakarta/app/config"
+	"jastip-jakarta/features/auth"
+	"jastip-jakarta/utils/middlewares"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type authService struct {
+	authData auth.AuthDataInterface
+}
+
+// dependency injection
+func New(repo auth.AuthDataInterface) auth.AuthServiceInterface {
+	return &authService{
+		authData: repo,
+	}
+}
+
+// CreateSession implements auth.AuthServiceInterface.
+func (a *authService) CreateSession(subjectId uint, audience string, client auth.ClientInfo) (*auth.Token, error) {
+	sessionId, err := randomToken(16)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := randomToken(32)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	err = a.authData.InsertSession(auth.Session{
+		ID:               sessionId,
+		SubjectID:        subjectId,
+		Audience:         audience,
+		RefreshTokenHash: hashToken(secret),
+		UserAgent:        client.UserAgent,
+		IPAddress:        client.IPAddress,
+		ExpiresAt:        now.Add(refreshTokenExpiry()),
+		LastUsedAt:       now,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return issueToken(subjectId, audience, sessionId, secret)
+}
+
+// Refresh implements auth.AuthServiceInterface.
+// Refresh token dirotasi setiap kali dipakai. Jika token lama dipakai ulang, session dianggap bocor dan dicabut.
+func (a *authService) Refresh(refreshToken, audience string) (*auth.Token, error) {
+	sessionId, secret, found := strings.Cut(refreshToken, ".")
+	if !found || sessionId == "" || secret == "" {
+		return nil, errors.New("refresh token tidak valid")
+	}
+
+	session, err := a.authData.SelectSessionById(sessionId)
+	if err != nil {
+		return nil, errors.New("refresh token tidak valid")
+	}
+	if session.Audience != audience || session.RevokedAt != nil || time.Now().After(session.ExpiresAt) {
+		return nil, errors.New("refresh token tidak valid")
+	}
+
+	oldHash := hashToken(secret)
+	if subtle.ConstantTimeCompare([]byte(oldHash), []byte(session.RefreshTokenHash)) != 1 {
+		a.authData.RevokeSession(sessionId)
+		return nil, errors.New("refresh token tidak valid")
+	}
+
+	newSecret, err := randomToken(32)
+	if err != nil {
+		return nil, err
+	}
+	err = a.authData.RotateRefreshToken(sessionId, oldHash, hashToken(newSecret), time.Now().Add(refreshTokenExpiry()))
+	if err != nil {
+		return nil, err
+	}
+
+	return issueToken(session.SubjectID, audience, sessionId, newSecret)
+}
+
+// Logout implements auth.AuthServiceInterface.
+func (a *authService) Logout(sessionId string) error {
+	if sessionId == "" {
+		return errors.New("session tidak ditemukan")
+	}
+	return a.authData.RevokeSession(sessionId)
+}
+
+// LogoutAll implements auth.AuthServiceInterface.
+func (a *authService) LogoutAll(subjectId uint, audience string) error {
+	return a.authData.RevokeAllSessions(subjectId, audience)
+}
+
+// LogoutOthers implements auth.AuthServiceInterface.
+// Session yang sedang dipakai tetap aktif, semua perangkat lain dikeluarkan.
+func (a *authService) LogoutOthers(subjectId uint, audience, keepSessionId string) error {
+	if keepSessionId == "" {
+		return a.authData.RevokeAllSessions(subjectId, audience)
+	}
+	return a.authData.RevokeOtherSessions(subjectId, audience, keepSessionId)
+}
+
+// IsSessionActive implements auth.AuthServiceInterface.
+func (a *authService) IsSessionActive(sessionId string) (bool, error) {
+	session, err := a.authData.SelectSessionById(sessionId)
+	if err != nil {
+		return false, err
+	}
+	return session.RevokedAt == nil && time.Now().Before(session.ExpiresAt), nil
+}
+
+// IssueCode implements auth.AuthServiceInterface.
+// Kode 6 digit dikembalikan untuk dikirim ke pemilik akun, yang disimpan hanya hash-nya.
+func (a *authService) IssueCode(subjectId uint, audience, purpose string) (string, error) {
+	if err := a.checkCodeLock(subjectId, audience, purpose); err != nil {
+		return "", err
+	}
+
+	latest, err := a.authData.SelectLatestCode(subjectId, audience, purpose)
+	if err == nil && time.Since(latest.CreatedAt) < time.Duration(config.OTP_RESEND_INTERVAL)*time.Second {
+		return "", auth.ErrCodeCooldown
+	}
+
+	number, err := rand.Int(rand.Reader, big.NewInt(1000000))
+	if err != nil {
+		return "", err
+	}
+	code := fmt.Sprintf("%06d", number.Int64())
+
+	err = a.authData.InsertCode(auth.OneTimeCode{
+		SubjectID: subjectId,
+		Audience:  audience,
+		Purpose:   purpose,
+		CodeHash:  hashCode(subjectId, audience, purpose, code),
+		ExpiresAt: time.Now().Add(time.Duration(config.OTP_EXPIRY) * time.Minute),
+	})
+	if err != nil {
+		return "", err
+	}
+	return code, nil
+}
+
+// VerifyCode implements auth.AuthServiceInterface.
+// Kegagalan juga dihitung per akun dan kegunaan di semua kode dalam OTP_FAILURE_WINDOW,
+// sehingga meminta kode baru tidak memberi kesempatan menebak tanpa batas.
+func (a *authService) VerifyCode(subjectId uint, audience, purpose, code string) error {
+	if err := a.checkCodeLock(subjectId, audience, purpose); err != nil {
+		return err
+	}
+
+	latest, err := a.authData.SelectLatestCode(subjectId, audience, purpose)
+	if err != nil || latest.UsedAt != nil {
+		return errors.New("kode tidak valid")
+	}
+	if time.Now().After(latest.ExpiresAt) {
+		return errors.New("kode sudah kedaluwarsa, silahkan minta kode baru")
+	}
+	if latest.Attempts >= config.OTP_MAX_ATTEMPTS {
+		return errors.New("terlalu banyak percobaan, silahkan minta kode baru")
+	}
+
+	expected := hashCode(subjectId, audience, purpose, code)
+	if !hmac.Equal([]byte(expected), []byte(latest.CodeHash)) {
+		if err := a.authData.IncrementCodeAttempts(latest.ID); err != nil {
+			return err
+		}
+		if err := a.recordCodeFailure(subjectId, audience, purpose); err != nil {
+			return err
+		}
+		return errors.New("kode tidak valid")
+	}
+
+	if err := a.authData.MarkCodeUsed(latest.ID); err != nil {
+		return err
+	}
+	return a.authData.DeleteThrottle(auth.ThrottleOTP, audience, codeThrottleIdentifier(subjectId, purpose))
+}
+
+// checkCodeLock menolak permintaan dan verifikasi kode selama akun dikunci.
+func (a *authService) checkCodeLock(subjectId uint, audience, purpose string) error {
+	throttle, err := a.authData.SelectThrottle(auth.ThrottleOTP, audience, codeThrottleIdentifier(subjectId, purpose))
+	if err != nil {
+		return err
+	}
+	if throttle != nil && throttle.LockedUntil != nil && time.Now().Before(*throttle.LockedUntil) {
+		return auth.ErrCodeLocked
+	}
+	return nil
+}
+
+// recordCodeFailure memakai tabel pembatasan login sehingga kunci OTP juga bisa dibuka admin.
+func (a *authService) recordCodeFailure(subjectId uint, audience, purpose string) error {
+	identifier := codeThrottleIdentifier(subjectId, purpose)
+	windowStart := time.Now().Add(-time.Duration(config.OTP_FAILURE_WINDOW) * time.Minute)
+	throttle, err := a.authData.IncrementThrottle(auth.ThrottleOTP, audience, identifier, windowStart)
+	if err != nil {
+		return err
+	}
+	if throttle.Failures < config.OTP_MAX_FAILURES {
+		return nil
+	}
+	return a.authData.LockThrottle(throttle.ID, time.Now().Add(time.Duration(config.OTP_LOCKOUT)*time.Minute))
+}
+
+func codeThrottleIdentifier(subjectId uint, purpose string) string {
+	return fmt.Sprintf("%d:%s", subjectId, purpose)
+}
+
+// CheckLogin implements auth.AuthServiceInterface.
+func (a *authService) CheckLogin(audience, identifier, ipAddress string) error {
+	for _, key := range throttleKeys(identifier, ipAddress) {
+		throttle, err := a.authData.SelectThrottle(key.kind, audience, key.identifier)
+		if err != nil {
+			return err
+		}
+		if throttle != nil && throttle.LockedUntil != nil && time.Now().Before(*throttle.LockedUntil) {
+			return auth.ErrLoginLocked
+		}
+	}
+	return nil
+}
+
+// RecordLoginFailure implements auth.AuthServiceInterface.
+// Setelah batas kegagalan tercapai, lama kunci berlipat dua untuk setiap kegagalan berikutnya.
+func (a *authService) RecordLoginFailure(audience, identifier, ipAddress string) error {
+	windowStart := time.Now().Add(-time.Duration(config.LOGIN_ATTEMPT_WINDOW) * time.Minute)
+	for _, key := range throttleKeys(identifier, ipAddress) {
+		throttle, err := a.authData.IncrementThrottle(key.kind, audience, key.identifier, windowStart)
+		if err != nil {
+			return err
+		}
+
+		maxAttempts := config.LOGIN_MAX_ATTEMPTS
+		if key.kind == auth.ThrottleIP {
+			maxAttempts = config.LOGIN_IP_MAX_ATTEMPTS
+		}
+		if throttle.Failures < maxAttempts {
+			continue
+		}
+
+		err = a.authData.LockThrottle(throttle.ID, time.Now().Add(lockoutDuration(throttle.Failures-maxAttempts)))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RecordLoginSuccess implements auth.AuthServiceInterface.
+// Hanya catatan akun yang dihapus, catatan IP tetap berlaku agar satu akun valid tidak bisa dipakai untuk mereset hitungan IP.
+func (a *authService) RecordLoginSuccess(audience, identifier string) error {
+	return a.authData.DeleteThrottle(auth.ThrottleAccount, audience, normalizeIdentifier(identifier))
+}
+
+// GetLockedLogins implements auth.AuthServiceInterface.
+func (a *authService) GetLockedLogins() ([]auth.LoginThrottle, error) {
+	return a.authData.SelectLockedThrottles()
+}
+
+// UnlockLogin implements auth.AuthServiceInterface.
+func (a *authService) UnlockLogin(throttleId uint) error {
+	return a.authData.DeleteThrottleById(throttleId)
+}
+
+type throttleKey struct {
+	kind       string
+	identifier string
+}
+
+func throttleKeys(identifier, ipAddress string) []throttleKey {
+	keys := []throttleKey{{kind: auth.ThrottleAccount, identifier: normalizeIdentifier(identifier)}}
+	if ipAddress != "" {
+		keys = append(keys, throttleKey{kind: auth.ThrottleIP, identifier: ipAddress})
+	}
+	return keys
+}
+
+// normalizeIdentifier membuat bentuk kanonis dari input login. Nomor telepon dicari
+// sebagai angka, sehingga "081234", "0081234" dan "+81234" disamakan dengan "81234".
+func normalizeIdentifier(identifier string) string {
+	identifier = strings.ToLower(strings.TrimSpace(identifier))
+	if !strings.Contains(identifier, "@") {
+		if phone, err := strconv.Atoi(identifier); err == nil {
+			identifier = strconv.Itoa(phone)
+		}
+	}
+	if len(identifier) > 255 {
+		identifier = identifier[:255]
+	}
+	return identifier
+}
+
+func lockoutDuration(excess int) time.Duration {
+	maxLockout := time.Duration(config.LOGIN_LOCKOUT_MAX) * time.Minute
+	lockout := time.Duration(config.LOGIN_LOCKOUT) * time.Minute
+	for i := 0; i < excess && lockout < maxLockout; i++ {
+		lockout *= 2
+	}
+	if lockout > maxLockout {
+		lockout = maxLockout
+	}
+	return lockout
+}
+
+// hashCode memakai HMAC dengan secret aplikasi karena kode 6 digit mudah ditebak dari hash biasa.
+func hashCode(subjectId uint, audience, purpose, code string) string {
+	mac := hmac.New(sha256.New, []byte(config.JWT_SECRET))
+	mac.Write([]byte(fmt.Sprintf("%s:%d:%s:%s", audience, subjectId, purpose, code)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func issueToken(subjectId uint, audience, sessionId, secret string) (*auth.Token, error) {
+	expiresAt := time.Now().Add(time.Duration(config.ACCESS_TOKEN_EXPIRY) * time.Minute)
+	accessToken, err := middlewares.CreateToken(int(subjectId), audience, sessionId, expiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &auth.Token{
+		AccessToken:  accessToken,
+		RefreshToken: sessionId + "." + secret,
+		ExpiresAt:    expiresAt,
+	}, nil
+}
+
+func refreshTokenExpiry() time.Duration {
+	return time.Duration(config.REFRESH_TOKEN_EXPIRY) * 24 * time.Hour
+}
+
+func randomToken(size int) (string, error) {
+	random := make([]byte, size)
+	if _, err := rand.Read(random); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(random), nil
+}
+
+// hashToken menyimpan refresh token dalam bentuk hash agar kebocoran database tidak membocorkan token.
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -13,15 +13,17 @@ import (
 type UserOrder struct {
 	ID uint `gorm:"primaryKey" json:"id"`
 	gorm.Model
-	UserID         uint
-	ItemName       string
-	TrackingNumber string
-	OnlineStore    string
-	WhatsappNumber int
-	RegionCodeID   string
-	User           ud.User       `gorm:"foreignKey:UserID"`
-	Region         ad.RegionCode `gorm:"foreignKey:RegionCodeID"`
-	OrderDetail    OrderDetail
+	UserID          uint
+	ItemName        string
+	TrackingNumber  string
+	OnlineStore     string
+	WhatsappNumber  int
+	RegionCodeID    string
+	User            ud.User       `gorm:"foreignKey:UserID"`
+	Region          ad.RegionCode `gorm:"foreignKey:RegionCodeID"`
+	OrderDetail     OrderDetail
+	ConditionPhotos []ConditionPhoto `gorm:"foreignKey:UserOrderID"`
+	Pickup          *OrderPickup     `gorm:"foreignKey:UserOrderID"`
 }
 
 type OrderDetail struct {
@@ -33,12 +35,42 @@ type OrderDetail struct {
 	TrackingNumberJastip  string
 	DeliveryBatchID       *string `gorm:"default:null"`
 	EstimatedDeliveryTime *time.Time
+	ShelfLocation         string
+	ReceivedAt            *time.Time
+	StorageEndedAt        *time.Time
+	StorageFee            int
 	Admin                 ad.Admin         `gorm:"foreignKey:AdminID"`
 	DeliveryBatch         ad.DeliveryBatch `gorm:"foreignKey:DeliveryBatchID"`
 }
 
+type ShelfLocationHistory struct {
+	gorm.Model
+	UserOrderID  uint
+	AdminID      uint
+	FromLocation string
+	ToLocation   string
+}
+
+type ConditionPhoto struct {
+	gorm.Model
+	UserOrderID uint
+	AdminID     uint
+	Category    string
+	Photo       string
+}
+
+type OrderPickup struct {
+	gorm.Model
+	UserOrderID   uint `gorm:"uniqueIndex"`
+	AdminID       uint
+	RecipientName string
+	Photo         string
+	PickedUpAt    time.Time
+}
+
 type PhotoOrder struct {
 	gorm.Model
+	AdminID         uint
 	DeliveryBatchID string
 	PhotoPacked     string
 	PhotoReceived   string
@@ -57,6 +89,7 @@ func OrderDetailStatusToModel(updateStatus order.OrderDetail) OrderDetail {
 
 func PhotoOrderToModel(input order.PhotoOrder) PhotoOrder {
 	return PhotoOrder{
+		AdminID:         input.AdminID,
 		DeliveryBatchID: input.DeliveryBatchID,
 		PhotoPacked:     input.PhotoPacked,
 		PhotoReceived:   input.PhotoReceived,
@@ -68,11 +101,13 @@ func PhotoOrderToModel(input order.PhotoOrder) PhotoOrder {
 func (o PhotoOrder) ModelToPhotoOrder() order.PhotoOrder {
 	return order.PhotoOrder{
 		ID:              o.ID,
+		AdminID:         o.AdminID,
 		UserID:          o.UserID,
 		DeliveryBatchID: o.DeliveryBatchID,
 		PhotoPacked:     o.PhotoPacked,
 		PhotoReceived:   o.PhotoReceived,
 		RegionCodeID:    o.RegionCodeID,
+		Region:          o.Region.ModelToRegionCode(),
 	}
 }
 
@@ -94,7 +129,7 @@ func UserOrderUpdateToModel(input order.UpdateOrderByID) (UserOrder, OrderDetail
 		TrackingNumber: input.TrackingNumber,
 		OnlineStore:    input.OnlineStore,
 		WhatsappNumber: input.WhatsAppNumber,
-		RegionCodeID:     input.RegionCode,
+		RegionCodeID:   input.RegionCode,
 	}
 
 	orderDetail := OrderDetail{
@@ -108,15 +143,18 @@ func UserOrderUpdateToModel(input order.UpdateOrderByID) (UserOrder, OrderDetail
 
 func (uo UserOrder) ModelToUserOrderWait() order.UserOrder {
 	return order.UserOrder{
-		ID:             uo.ID,
-		UserID:         uo.UserID,
-		ItemName:       uo.ItemName,
-		TrackingNumber: uo.TrackingNumber,
-		OnlineStore:    uo.OnlineStore,
-		WhatsAppNumber: uo.WhatsappNumber,
-		Region:         uo.Region.ModelToRegionCode(),
-		User:           uo.User.ModelToUser(),
-		OrderDetails:   uo.OrderDetail.ModelToOrderDetail(),
+		ID:              uo.ID,
+		UserID:          uo.UserID,
+		ItemName:        uo.ItemName,
+		TrackingNumber:  uo.TrackingNumber,
+		OnlineStore:     uo.OnlineStore,
+		WhatsAppNumber:  uo.WhatsappNumber,
+		RegionCode:      uo.RegionCodeID,
+		Region:          uo.Region.ModelToRegionCode(),
+		User:            uo.User.ModelToUser(),
+		OrderDetails:    uo.OrderDetail.ModelToOrderDetail(),
+		ConditionPhotos: ModelToConditionPhotos(uo.ConditionPhotos),
+		Pickup:          uo.Pickup.ModelToOrderPickup(),
 	}
 }
 
@@ -131,6 +169,7 @@ func (o UserOrder) ModelToUserOrderWaits() *order.UserOrder {
 		TrackingNumber: o.TrackingNumber,
 		OnlineStore:    o.OnlineStore,
 		WhatsAppNumber: o.WhatsappNumber,
+		RegionCode:     o.RegionCodeID,
 		Region:         o.Region.ModelToRegionCode(),
 		User:           o.User.ModelToUser(),
 		OrderDetails:   o.OrderDetail.ModelToOrderDetail(),
@@ -145,6 +184,8 @@ func OrderDetailToModel(input order.OrderDetail) OrderDetail {
 		DeliveryBatchID:       input.DeliveryBatchID,
 		TrackingNumberJastip:  input.TrackingNumberJastip,
 		EstimatedDeliveryTime: input.EstimatedDeliveryTime,
+		ShelfLocation:         input.ShelfLocation,
+		ReceivedAt:            input.ReceivedAt,
 	}
 }
 
@@ -157,5 +198,68 @@ func (o OrderDetail) ModelToOrderDetail() order.OrderDetail {
 		DeliveryBatchID:       o.DeliveryBatchID,
 		EstimatedDeliveryTime: o.EstimatedDeliveryTime,
 		TrackingNumberJastip:  o.TrackingNumberJastip,
+		ShelfLocation:         o.ShelfLocation,
+		ReceivedAt:            o.ReceivedAt,
+		StorageEndedAt:        o.StorageEndedAt,
+		StorageFee:            o.StorageFee,
+	}
+}
+
+func (h ShelfLocationHistory) ModelToShelfLocationHistory() order.ShelfLocationHistory {
+	return order.ShelfLocationHistory{
+		ID:           h.ID,
+		UserOrderID:  h.UserOrderID,
+		AdminID:      h.AdminID,
+		FromLocation: h.FromLocation,
+		ToLocation:   h.ToLocation,
+		CreatedAt:    h.CreatedAt,
+	}
+}
+
+func ConditionPhotoToModel(input order.ConditionPhoto) ConditionPhoto {
+	return ConditionPhoto{
+		UserOrderID: input.UserOrderID,
+		AdminID:     input.AdminID,
+		Category:    input.Category,
+		Photo:       input.Photo,
+	}
+}
+
+func ModelToConditionPhotos(photos []ConditionPhoto) []order.ConditionPhoto {
+	var result []order.ConditionPhoto
+	for _, p := range photos {
+		result = append(result, order.ConditionPhoto{
+			ID:          p.ID,
+			UserOrderID: p.UserOrderID,
+			AdminID:     p.AdminID,
+			Category:    p.Category,
+			Photo:       p.Photo,
+			CreatedAt:   p.CreatedAt,
+		})
+	}
+	return result
+}
+
+func OrderPickupToModel(input order.OrderPickup) OrderPickup {
+	return OrderPickup{
+		UserOrderID:   input.UserOrderID,
+		AdminID:       input.AdminID,
+		RecipientName: input.RecipientName,
+		Photo:         input.Photo,
+		PickedUpAt:    input.PickedUpAt,
+	}
+}
+
+func (p *OrderPickup) ModelToOrderPickup() *order.OrderPickup {
+	if p == nil {
+		return nil
+	}
+	return &order.OrderPickup{
+		ID:            p.ID,
+		UserOrderID:   p.UserOrderID,
+		AdminID:       p.AdminID,
+		RecipientName: p.RecipientName,
+		Photo:         p.Photo,
+		PickedUpAt:    p.PickedUpAt,
 	}
 }
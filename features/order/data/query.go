@@ -1,10 +1,12 @@
 package data
 
 import (
+	"errors"
 	"fmt"
+	ad "jastip-jakarta/features/admin/data"
 	"jastip-jakarta/features/order"
-	"jastip-jakarta/utils/cloudinary"
 	"jastip-jakarta/utils/csv"
+	"jastip-jakarta/utils/storage"
 	"log"
 	"mime/multipart"
 	"time"
@@ -13,16 +15,16 @@ import (
 )
 
 type orderQuery struct {
-	db  *gorm.DB
-	cld cloudinary.CloudinaryUploaderInterface
-	csv csv.CSVGeneratorInterface
+	db       *gorm.DB
+	uploader storage.UploaderInterface
+	csv      csv.CSVGeneratorInterface
 }
 
-func New(db *gorm.DB, cloudinaryUploader cloudinary.CloudinaryUploaderInterface, csvGenerator csv.CSVGeneratorInterface) order.OrderDataInterface {
+func New(db *gorm.DB, uploader storage.UploaderInterface, csvGenerator csv.CSVGeneratorInterface) order.OrderDataInterface {
 	return &orderQuery{
-		db:  db,
-		cld: cloudinaryUploader,
-		csv: csvGenerator,
+		db:       db,
+		uploader: uploader,
+		csv:      csvGenerator,
 	}
 }
 
@@ -96,6 +98,8 @@ func (o *orderQuery) SelectById(IdOrder uint) (*order.UserOrder, error) {
 	err := o.db.Preload("User").
 		Preload("Region").
 		Preload("OrderDetail").
+		Preload("ConditionPhotos").
+		Preload("Pickup").
 		First(&userOrderData, IdOrder).Error
 	if err != nil {
 		log.Printf("Error finding order with ID %d: %v", IdOrder, err)
@@ -119,6 +123,10 @@ func (o *orderQuery) InsertOrderDetail(adminIdLogin int, userOrderId uint, input
 	adminID := uint(adminIdLogin)
 	newOrder.AdminID = &adminID
 
+	// Waktu diterima menjadi awal perhitungan lama penyimpanan di gudang
+	receivedAt := time.Now()
+	newOrder.ReceivedAt = &receivedAt
+
 	// Assign UserOrderID dari userOrder yang sudah ditemukan
 	newOrder.UserOrderID = userOrder.ID
 
@@ -136,6 +144,19 @@ func (o *orderQuery) InsertOrderDetail(adminIdLogin int, userOrderId uint, input
 		return result.Error
 	}
 
+	// Catat lokasi rak awal saat barang diterima
+	if newOrder.ShelfLocation != "" {
+		history := ShelfLocationHistory{
+			UserOrderID: userOrder.ID,
+			AdminID:     adminID,
+			ToLocation:  newOrder.ShelfLocation,
+		}
+		result = o.db.Create(&history)
+		if result.Error != nil {
+			return result.Error
+		}
+	}
+
 	return nil
 }
 
@@ -281,6 +302,53 @@ func (o *orderQuery) UpdateEstimationForOrders(code, batch string, estimation *t
 		Update("estimated_delivery_time", estimation).Error
 }
 
+// ShipBatch implements order.OrderDataInterface.
+// Batch ditandai dikirim, biaya penyimpanan dikunci dan estimasi diisi dalam satu transaksi.
+// Update shipped_at hanya berhasil jika batch belum dikirim, sehingga dua request bersamaan
+// tidak bisa sama-sama lolos. storageFees berisi biaya per ID order.
+func (o *orderQuery) ShipBatch(batch string, shippedAt time.Time, storageFees map[uint]int, estimations []order.BatchEstimation) error {
+	return o.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&ad.DeliveryBatch{}).
+			Where("id = ? AND shipped_at IS NULL", batch).
+			Update("shipped_at", shippedAt)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.New("batch sudah ditandai dikirim")
+		}
+
+		for userOrderId, fee := range storageFees {
+			err := tx.Model(&OrderDetail{}).
+				Where("user_order_id = ?", userOrderId).
+				Updates(map[string]interface{}{
+					"storage_ended_at": shippedAt,
+					"storage_fee":      fee,
+				}).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		// Hanya mengisi pesanan yang belum memiliki estimasi, sehingga estimasi manual tidak tertimpa
+		for _, estimation := range estimations {
+			subQuery := tx.Model(&UserOrder{}).
+				Select("id").
+				Where("region_code_id = ?", estimation.RegionCode)
+
+			err := tx.Model(&OrderDetail{}).
+				Where("user_order_id IN (?)", subQuery).
+				Where("delivery_batch_id = ?", batch).
+				Where("estimated_delivery_time IS NULL").
+				Update("estimated_delivery_time", estimation.EstimatedDelivery).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
 // UpdateOrderStatus implements order.OrderDataInterface.
 func (o *orderQuery) UpdateOrderStatus(userOrderId uint, status string) error {
 	return o.db.Model(&OrderDetail{}).
@@ -289,37 +357,79 @@ func (o *orderQuery) UpdateOrderStatus(userOrderId uint, status string) error {
 }
 
 // UploadFotoPacked implements order.OrderDataInterface.
-func (o *orderQuery) UploadFotoPacked(inputOrder order.PhotoOrder, photoPacked *multipart.FileHeader) error {
-	imageURL, err := o.cld.UploadImage(photoPacked)
+func (o *orderQuery) UploadFotoPacked(inputOrder order.PhotoOrder, photoPacked *multipart.FileHeader) (uint, error) {
+	imageKey, err := o.uploader.UploadPrivateImage(photoPacked)
 	if err != nil {
-		return err
+		return 0, err
 	}
 
 	dataGorm := PhotoOrderToModel(inputOrder)
-	dataGorm.PhotoPacked = imageURL
+	dataGorm.PhotoPacked = imageKey
 
 	result := o.db.Create(&dataGorm)
 	if result.Error != nil {
-		return result.Error
+		o.uploader.DeleteImage(imageKey)
+		return 0, result.Error
 	}
 
-	return nil
+	return dataGorm.ID, nil
 }
 
 // UploadFotoReceived implements order.OrderDataInterface.
 func (o *orderQuery) UploadFotoReceived(idFoto uint, photoReceived *multipart.FileHeader) error {
-	imageURL, err := o.cld.UploadImage(photoReceived)
+	return o.replacePhotoOrder(idFoto, "photo_received", photoReceived)
+}
+
+// ReplaceFotoPacked implements order.OrderDataInterface.
+func (o *orderQuery) ReplaceFotoPacked(idFoto uint, photoPacked *multipart.FileHeader) error {
+	return o.replacePhotoOrder(idFoto, "photo_packed", photoPacked)
+}
+
+// replacePhotoOrder mengganti satu kolom foto pada PhotoOrder lalu menghapus foto lama dari storage.
+func (o *orderQuery) replacePhotoOrder(idFoto uint, column string, photo *multipart.FileHeader) error {
+	var current PhotoOrder
+	if err := o.db.First(&current, idFoto).Error; err != nil {
+		return errors.New("foto tidak ditemukan")
+	}
+
+	imageKey, err := o.uploader.UploadPrivateImage(photo)
 	if err != nil {
 		return err
 	}
 
-	tx := o.db.Model(&PhotoOrder{}).Where("id = ?", idFoto).Update("photo_received", imageURL)
+	tx := o.db.Model(&PhotoOrder{}).Where("id = ?", idFoto).Update(column, imageKey)
 	if tx.Error != nil {
+		o.uploader.DeleteImage(imageKey)
 		return tx.Error
 	}
+
+	oldURL := current.PhotoReceived
+	if column == "photo_packed" {
+		oldURL = current.PhotoPacked
+	}
+	if err := o.uploader.DeleteImage(oldURL); err != nil {
+		log.Printf("error deleting old photo %s: %v", oldURL, err)
+	}
 	return nil
 }
 
+// DeletePhotoOrder implements order.OrderDataInterface.
+func (o *orderQuery) DeletePhotoOrder(idFoto uint) error {
+	var current PhotoOrder
+	if err := o.db.First(&current, idFoto).Error; err != nil {
+		return errors.New("foto tidak ditemukan")
+	}
+
+	if err := o.db.Delete(&current).Error; err != nil {
+		return err
+	}
+
+	if err := o.uploader.DeleteImage(current.PhotoPacked); err != nil {
+		return err
+	}
+	return o.uploader.DeleteImage(current.PhotoReceived)
+}
+
 // FetchOrdersByBatch implements order.OrderDataInterface.
 func (o *orderQuery) FetchOrdersByBatch(batch string) ([]order.UserOrder, error) {
 	var userOrders []UserOrder
@@ -391,6 +501,18 @@ func (o *orderQuery) GetFoto(batch string, code string, userId int) (*order.Phot
 	return &result, nil
 }
 
+// SelectPhotoOrderById implements order.OrderDataInterface.
+func (o *orderQuery) SelectPhotoOrderById(idFoto uint) (*order.PhotoOrder, error) {
+	var photoOrder PhotoOrder
+	err := o.db.First(&photoOrder, idFoto).Error
+	if err != nil {
+		return nil, err
+	}
+
+	result := photoOrder.ModelToPhotoOrder()
+	return &result, nil
+}
+
 // SearchOrders implements order.OrderDataInterface.
 func (o *orderQuery) SearchOrders(searchQuery string) ([]order.UserOrder, error) {
 	var userOrders []UserOrder
@@ -481,3 +603,203 @@ func (o *orderQuery) FetchRegionStatsByBatch(batch string) ([]order.RegionBatchS
 
 	return results, nil
 }
+
+// SelectRegionCodesByBatch implements order.OrderDataInterface.
+func (o *orderQuery) SelectRegionCodesByBatch(batch string) ([]string, error) {
+	var codes []string
+
+	err := o.db.Model(&UserOrder{}).
+		Joins("JOIN order_details ON order_details.user_order_id = user_orders.id").
+		Where("order_details.delivery_batch_id = ?", batch).
+		Distinct().
+		Pluck("user_orders.region_code_id", &codes).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+// SelectByTrackingNumberJastip implements order.OrderDataInterface.
+func (o *orderQuery) SelectByTrackingNumberJastip(trackingNumber string) (*order.UserOrder, error) {
+	var userOrderData UserOrder
+	err := o.db.Preload("User").
+		Preload("Region").
+		Preload("OrderDetail").
+		Joins("JOIN order_details ON order_details.user_order_id = user_orders.id").
+		Where("order_details.tracking_number_jastip = ?", trackingNumber).
+		First(&userOrderData).Error
+	if err != nil {
+		return nil, err
+	}
+	result := userOrderData.ModelToUserOrderWait()
+	return &result, nil
+}
+
+// UpdateShelfLocation implements order.OrderDataInterface.
+func (o *orderQuery) UpdateShelfLocation(adminIdLogin int, userOrderId uint, location string) error {
+	return o.db.Transaction(func(tx *gorm.DB) error {
+		var detail OrderDetail
+		err := tx.Where("user_order_id = ?", userOrderId).Order("id DESC").First(&detail).Error
+		if err != nil {
+			return err
+		}
+
+		if detail.ShelfLocation == location {
+			return errors.New("barang sudah berada di lokasi tersebut")
+		}
+
+		err = tx.Model(&OrderDetail{}).Where("user_order_id = ?", userOrderId).Update("shelf_location", location).Error
+		if err != nil {
+			return err
+		}
+
+		history := ShelfLocationHistory{
+			UserOrderID:  userOrderId,
+			AdminID:      uint(adminIdLogin),
+			FromLocation: detail.ShelfLocation,
+			ToLocation:   location,
+		}
+		return tx.Create(&history).Error
+	})
+}
+
+// SelectShelfLocationHistory implements order.OrderDataInterface.
+func (o *orderQuery) SelectShelfLocationHistory(userOrderId uint) ([]order.ShelfLocationHistory, error) {
+	var histories []ShelfLocationHistory
+	err := o.db.Where("user_order_id = ?", userOrderId).Order("created_at ASC").Find(&histories).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var responseHistories []order.ShelfLocationHistory
+	for _, history := range histories {
+		responseHistories = append(responseHistories, history.ModelToShelfLocationHistory())
+	}
+	return responseHistories, nil
+}
+
+// SelectByTrackingNumber implements order.OrderDataInterface.
+// Mencari berdasarkan nomor resi jastip maupun nomor resi toko online.
+func (o *orderQuery) SelectByTrackingNumber(trackingNumber string) (*order.UserOrder, error) {
+	var userOrderData UserOrder
+	err := o.db.Preload("User").
+		Preload("Region").
+		Preload("OrderDetail").
+		Joins("JOIN order_details ON order_details.user_order_id = user_orders.id").
+		Where("order_details.tracking_number_jastip = ? OR user_orders.tracking_number = ?", trackingNumber, trackingNumber).
+		First(&userOrderData).Error
+	if err != nil {
+		return nil, err
+	}
+	result := userOrderData.ModelToUserOrderWait()
+	return &result, nil
+}
+
+// SelectPickList implements order.OrderDataInterface.
+func (o *orderQuery) SelectPickList(batch, code string) ([]order.UserOrder, error) {
+	var userOrders []UserOrder
+
+	query := o.db.Preload("User").
+		Preload("Region").
+		Preload("OrderDetail").
+		Joins("JOIN order_details ON order_details.user_order_id = user_orders.id").
+		Where("order_details.delivery_batch_id = ?", batch)
+
+	if code != "" {
+		query = query.Where("user_orders.region_code_id = ?", code)
+	}
+
+	err := query.Order("order_details.shelf_location ASC").
+		Order("user_orders.region_code_id ASC").
+		Find(&userOrders).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var responseOrders []order.UserOrder
+	for _, uo := range userOrders {
+		responseOrders = append(responseOrders, uo.ModelToUserOrderWait())
+	}
+	return responseOrders, nil
+}
+
+// SelectStoredOrders implements order.OrderDataInterface.
+// Mengambil order yang sudah diterima di gudang namun batch-nya belum dikirim.
+func (o *orderQuery) SelectStoredOrders() ([]order.UserOrder, error) {
+	var userOrders []UserOrder
+
+	err := o.db.Preload("User").
+		Preload("Region").
+		Preload("OrderDetail").
+		Joins("JOIN order_details ON order_details.user_order_id = user_orders.id").
+		Where("order_details.received_at IS NOT NULL AND order_details.storage_ended_at IS NULL").
+		Order("order_details.received_at ASC").
+		Find(&userOrders).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var responseOrders []order.UserOrder
+	for _, uo := range userOrders {
+		responseOrders = append(responseOrders, uo.ModelToUserOrderWait())
+	}
+	return responseOrders, nil
+}
+
+// InsertConditionPhoto implements order.OrderDataInterface.
+func (o *orderQuery) InsertConditionPhoto(input order.ConditionPhoto, photo *multipart.FileHeader) error {
+	imageKey, err := o.uploader.UploadPrivateImage(photo)
+	if err != nil {
+		return err
+	}
+
+	dataGorm := ConditionPhotoToModel(input)
+	dataGorm.Photo = imageKey
+
+	if err := o.db.Create(&dataGorm).Error; err != nil {
+		o.uploader.DeleteImage(imageKey)
+		return err
+	}
+	return nil
+}
+
+// DeleteConditionPhoto implements order.OrderDataInterface.
+func (o *orderQuery) DeleteConditionPhoto(userOrderId uint, photoId uint) error {
+	var current ConditionPhoto
+	err := o.db.Where("id = ? AND user_order_id = ?", photoId, userOrderId).First(&current).Error
+	if err != nil {
+		return errors.New("foto tidak ditemukan")
+	}
+
+	if err := o.db.Delete(&current).Error; err != nil {
+		return err
+	}
+	return o.uploader.DeleteImage(current.Photo)
+}
+
+// InsertPickup implements order.OrderDataInterface.
+// Bukti pengambilan disimpan bersamaan dengan perubahan status menjadi "Sudah Diambil".
+func (o *orderQuery) InsertPickup(input order.OrderPickup, photo *multipart.FileHeader) error {
+	imageKey, err := o.uploader.UploadPrivateImage(photo)
+	if err != nil {
+		return err
+	}
+
+	dataGorm := OrderPickupToModel(input)
+	dataGorm.Photo = imageKey
+
+	err = o.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&dataGorm).Error; err != nil {
+			return err
+		}
+		return tx.Model(&OrderDetail{}).
+			Where("user_order_id = ?", input.UserOrderID).
+			Update("status", "Sudah Diambil").Error
+	})
+	if err != nil {
+		o.uploader.DeleteImage(imageKey)
+		return err
+	}
+	return nil
+}
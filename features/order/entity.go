@@ -3,24 +3,39 @@ package order
 import (
 	ad "jastip-jakarta/features/admin"
 	ud "jastip-jakarta/features/user"
+	"math"
 	"mime/multipart"
 	"time"
 )
 
+// ChargeableWeight adalah berat yang ditagihkan, dibulatkan ke atas per kilogram untuk
+// setiap barang. Berat dibulatkan ke gram lebih dulu agar sisa pembulatan float tidak
+// menambah satu kilogram.
+func ChargeableWeight(weight float64) int {
+	return int(math.Ceil(math.Round(weight*1000) / 1000))
+}
+
+// ItemPrice adalah harga kirim satu barang, dipakai di semua tagihan dan manifest.
+func ItemPrice(weight float64, pricePerKg int) int {
+	return ChargeableWeight(weight) * pricePerKg
+}
+
 type UserOrder struct {
-	ID             uint
-	UserID         uint
-	ItemName       string
-	TrackingNumber string
-	OnlineStore    string
-	WhatsAppNumber int
-	RegionCode     string
-	Region         ad.RegionCode
-	User           ud.User
-	CreatedAt      time.Time
-	UpdatedAt      time.Time
-	OrderDetails   OrderDetail
-	PhotoOrders    PhotoOrder
+	ID              uint
+	UserID          uint
+	ItemName        string
+	TrackingNumber  string
+	OnlineStore     string
+	WhatsAppNumber  int
+	RegionCode      string
+	Region          ad.RegionCode
+	User            ud.User
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+	OrderDetails    OrderDetail
+	PhotoOrders     PhotoOrder
+	ConditionPhotos []ConditionPhoto
+	Pickup          *OrderPickup
 }
 
 type DeliveryBatchWithRegion struct {
@@ -51,14 +66,56 @@ type OrderDetail struct {
 	DeliveryBatchID       *string
 	TrackingNumberJastip  string
 	EstimatedDeliveryTime *time.Time
+	ShelfLocation         string
+	ReceivedAt            *time.Time
+	StorageEndedAt        *time.Time
+	StorageFee            int
 	DeliveryBatch         ad.DeliveryBatch
 	Admin                 ad.Admin
 	CreatedAt             time.Time
 	UpdatedAt             time.Time
 }
 
+type StorageAging struct {
+	UserOrder      UserOrder
+	DaysStored     int
+	ChargeableDays int
+	StorageFee     int
+}
+
+type ShelfLocationHistory struct {
+	ID           uint
+	UserOrderID  uint
+	AdminID      uint
+	FromLocation string
+	ToLocation   string
+	CreatedAt    time.Time
+}
+
+// ConditionPhoto adalah foto kondisi barang per order yang diambil saat diterima di gudang Jakarta.
+type ConditionPhoto struct {
+	ID          uint
+	UserOrderID uint
+	AdminID     uint
+	Category    string
+	Photo       string
+	Thumbnail   string
+	CreatedAt   time.Time
+}
+
+// OrderPickup adalah bukti serah terima order kepada customer di Perwakilan.
+type OrderPickup struct {
+	ID            uint
+	UserOrderID   uint
+	AdminID       uint
+	RecipientName string
+	Photo         string
+	PickedUpAt    time.Time
+}
+
 type PhotoOrder struct {
 	ID              uint
+	AdminID         uint
 	DeliveryBatchID string
 	PhotoPacked     string
 	PhotoReceived   string
@@ -78,6 +135,13 @@ type RegionBatchStats struct {
 	TotalPrice   int
 }
 
+type BatchEstimation struct {
+	RegionCode        string
+	Region            string
+	EarliestDelivery  time.Time
+	EstimatedDelivery time.Time
+}
+
 // interface untuk Data Layer
 type OrderDataInterface interface {
 	InsertUserOrder(userIdLogin int, inputOrder UserOrder) error
@@ -94,14 +158,28 @@ type OrderDataInterface interface {
 	SelectOrderByUserOrderNameUser(code, batch, name string) ([]UserOrder, error)
 	UpdateEstimationForOrders(code, batch string, estimation *time.Time) error
 	UpdateOrderStatus(userOrderId uint, status string) error
-	UploadFotoPacked(inputOrder PhotoOrder, photoPacked *multipart.FileHeader) error
+	UploadFotoPacked(inputOrder PhotoOrder, photoPacked *multipart.FileHeader) (uint, error)
 	UploadFotoReceived(idFoto uint, photoReceived *multipart.FileHeader) error
 	FetchOrdersByBatch(batch string) ([]UserOrder, error)
 	GenerateCSVByBatch(batch string, filePath string) error
 	GetFoto(batch, code string, userId int) (*PhotoOrder, error)
+	SelectPhotoOrderById(idFoto uint) (*PhotoOrder, error)
 	SearchOrders(searchQuery string) ([]UserOrder, error)
 	UpdateOrderByID(orderID uint, inputOrder UpdateOrderByID) error
 	FetchRegionStatsByBatch(batch string) ([]RegionBatchStats, error)
+	SelectRegionCodesByBatch(batch string) ([]string, error)
+	ShipBatch(batch string, shippedAt time.Time, storageFees map[uint]int, estimations []BatchEstimation) error
+	SelectByTrackingNumberJastip(trackingNumber string) (*UserOrder, error)
+	UpdateShelfLocation(adminIdLogin int, userOrderId uint, location string) error
+	SelectShelfLocationHistory(userOrderId uint) ([]ShelfLocationHistory, error)
+	SelectByTrackingNumber(trackingNumber string) (*UserOrder, error)
+	SelectPickList(batch, code string) ([]UserOrder, error)
+	SelectStoredOrders() ([]UserOrder, error)
+	InsertConditionPhoto(input ConditionPhoto, photo *multipart.FileHeader) error
+	DeleteConditionPhoto(userOrderId uint, photoId uint) error
+	ReplaceFotoPacked(idFoto uint, photoPacked *multipart.FileHeader) error
+	DeletePhotoOrder(idFoto uint) error
+	InsertPickup(input OrderPickup, photo *multipart.FileHeader) error
 }
 
 // interface untuk Service Layer
@@ -111,6 +189,8 @@ type OrderServiceInterface interface {
 	GetUserOrderWait(userIdLogin int) ([]UserOrder, error)
 	GetUserOrderProcess(userIdLogin int) ([]UserOrder, error)
 	GetById(IdOrder uint) (*UserOrder, error)
+	GetOrderForUser(userIdLogin int, userOrderId uint) (*UserOrder, error)
+	GetOrderForAdmin(adminIdLogin int, userOrderId uint) (*UserOrder, error)
 	SearchUserOrder(userIdLogin int, itemName string) ([]UserOrder, error)
 	CreateOrderDetail(adminIdLogin int, userOrderId uint, inputOrder OrderDetail) error
 	GetAllUserOrderWait(adminIdLogin int) ([]UserOrder, error)
@@ -119,11 +199,31 @@ type OrderServiceInterface interface {
 	GetOrderByUserOrderNameUser(adminIdLogin int, code, batch, name string) ([]UserOrder, error)
 	UpdateEstimationForOrders(adminIdLogin int, code, batch string, estimation *time.Time) error
 	UpdateOrderStatus(adminIdLogin int, userOrderId uint, status string) error
-	UploadFotoPacked(adminIdLogin int, inputOrder PhotoOrder, photoPacked *multipart.FileHeader) error
+	UploadFotoPacked(adminIdLogin int, inputOrder PhotoOrder, photoPacked *multipart.FileHeader) (uint, error)
 	UploadFotoReceived(adminIdLogin int, idFoto uint, photoReceived *multipart.FileHeader) error
-	GenerateCSVByBatch(batch, filePath string) error
-	GetFoto(batch, code string, userId int) (*PhotoOrder, error)
+	GenerateCSVByBatch(adminIdLogin int, batch, filePath string) error
+	GetFotoForUser(userIdLogin int, batch, code string, userId int) (*PhotoOrder, error)
+	GetFotoForAdmin(adminIdLogin int, batch, code string, userId int) (*PhotoOrder, error)
+	GetConditionPhotosForUser(userIdLogin int, userOrderId uint) ([]ConditionPhoto, error)
+	GetConditionPhotosForAdmin(adminIdLogin int, userOrderId uint) ([]ConditionPhoto, error)
 	SearchOrders(adminIdLogin int, searchQuery string) ([]UserOrder, error)
 	UpdateOrderByID(adminIdLogin int, orderID uint, inputOrder UpdateOrderByID) error
 	FetchRegionStatsByBatch(adminIdLogin int, batch string) ([]RegionBatchStats, error)
+	ShipDeliveryBatch(adminIdLogin int, batch string) ([]BatchEstimation, error)
+	GenerateManifestByBatch(adminIdLogin int, batch, code, format, filePath string) error
+	GenerateOrderLabel(adminIdLogin int, orderID uint, format, filePath string) error
+	GenerateBoxLabel(adminIdLogin int, batch, code, format, filePath string) error
+	GenerateBatchLabels(adminIdLogin int, batch, code, size, filePath string) error
+	ScanLabel(adminIdLogin int, code string) ([]UserOrder, error)
+	MoveShelfLocation(adminIdLogin int, userOrderId uint, location string) error
+	GetShelfLocationHistory(adminIdLogin int, userOrderId uint) ([]ShelfLocationHistory, error)
+	FindOrderLocation(adminIdLogin int, trackingNumber string) (*UserOrder, error)
+	GetPickList(adminIdLogin int, batch, code string) ([]UserOrder, error)
+	GetStorageAging(adminIdLogin int, minDays int) ([]StorageAging, error)
+	UploadConditionPhotos(adminIdLogin int, userOrderId uint, category string, photos []*multipart.FileHeader) error
+	DeleteConditionPhoto(adminIdLogin int, userOrderId uint, photoId uint) error
+	ReplaceFotoPacked(adminIdLogin int, idFoto uint, photoPacked *multipart.FileHeader) error
+	DeletePhotoOrder(adminIdLogin int, idFoto uint) error
+	RecordPickup(adminIdLogin int, userOrderId uint, recipientName string, photo *multipart.FileHeader) error
+	GetPickupForUser(userIdLogin int, userOrderId uint) (*OrderPickup, error)
 }
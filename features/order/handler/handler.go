@@ -88,23 +88,56 @@ func (handler *OrderHandler) GetUserOrderWait(c echo.Context) error {
 }
 
 func (handler *OrderHandler) GetOrderById(c echo.Context) error {
+	userIdLogin := middlewares.ExtractTokenUserId(c)
+	if userIdLogin == 0 {
+		return c.JSON(http.StatusUnauthorized, responses.WebResponse("Silahkan login terlebih dahulu", nil))
+	}
+
 	orderIdStr := c.Param("order_id")
 	orderId, err := strconv.ParseUint(orderIdStr, 10, 64)
 	if err != nil {
 		return c.JSON(http.StatusBadRequest, responses.WebResponse("ID order tidak valid", nil))
 	}
 
-	result, errSelect := handler.orderService.GetById(uint(orderId))
+	result, errSelect := handler.orderService.GetOrderForUser(userIdLogin, uint(orderId))
 	if errSelect != nil {
 		return c.JSON(http.StatusNotFound, responses.WebResponse("Order tidak ditemukan", nil))
 	}
 
+	// Foto kondisi dan bukti pengambilan ditampilkan dalam bentuk signed URL
+	result.ConditionPhotos, _ = handler.orderService.GetConditionPhotosForUser(userIdLogin, uint(orderId))
+	result.Pickup, _ = handler.orderService.GetPickupForUser(userIdLogin, uint(orderId))
+
+	var orderResult = CoreToResponseUserOrderById(*result)
+	return c.JSON(http.StatusOK, responses.WebResponse("success read data.", orderResult))
+}
+
+func (handler *OrderHandler) GetOrderByIdAdmin(c echo.Context) error {
+	adminIdLogin := middlewares.ExtractTokenAdminId(c)
+	if adminIdLogin == 0 {
+		return c.JSON(http.StatusUnauthorized, responses.WebResponse("Silahkan login terlebih dahulu", nil))
+	}
+
+	orderId, err := strconv.ParseUint(c.Param("order_id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, responses.WebResponse("ID order tidak valid", nil))
+	}
+
+	result, errSelect := handler.orderService.GetOrderForAdmin(adminIdLogin, uint(orderId))
+	if errSelect != nil {
+		return c.JSON(http.StatusNotFound, responses.WebResponse(errSelect.Error(), nil))
+	}
+
+	// foto mentah dari database tidak boleh ikut terkirim, foto kondisi diganti signed URL sesuai akses admin
+	result.ConditionPhotos, _ = handler.orderService.GetConditionPhotosForAdmin(adminIdLogin, uint(orderId))
+	result.Pickup = nil
+
 	var orderResult = CoreToResponseUserOrderById(*result)
 	return c.JSON(http.StatusOK, responses.WebResponse("success read data.", orderResult))
 }
 
 func (handler *OrderHandler) CreateOrderDetail(c echo.Context) error {
-	adminIdLogin := middlewares.ExtractTokenUserId(c)
+	adminIdLogin := middlewares.ExtractTokenAdminId(c)
 	if adminIdLogin == 0 {
 		return c.JSON(http.StatusUnauthorized, responses.WebResponse("Silahkan login terlebih dahulu", nil))
 	}
@@ -146,7 +179,10 @@ func (handler *OrderHandler) GetUserOrderProcess(c echo.Context) error {
 		return c.JSON(http.StatusInternalServerError, responses.WebResponse(err.Error(), nil))
 	}
 
-	groupedResponses := CoreToGroupedOrderResponse(userOrders, handler.orderService.GetFoto)
+	getFoto := func(batch, code string, userId int) (*order.PhotoOrder, error) {
+		return handler.orderService.GetFotoForUser(userIdLogin, batch, code, userId)
+	}
+	groupedResponses := CoreToGroupedOrderResponse(userOrders, getFoto)
 
 	return c.JSON(http.StatusOK, responses.WebResponse("Berhasil mendapatkan orderan yang diproses", groupedResponses))
 }
@@ -176,7 +212,7 @@ func (handler *OrderHandler) SearchUserOrder(c echo.Context) error {
 }
 
 func (handler *OrderHandler) GetAllUserOrderWait(c echo.Context) error {
-	adminIdLogin := middlewares.ExtractTokenUserId(c)
+	adminIdLogin := middlewares.ExtractTokenAdminId(c)
 	if adminIdLogin == 0 {
 		return c.JSON(http.StatusUnauthorized, responses.WebResponse("Silahkan login terlebih dahulu", nil))
 	}
@@ -195,7 +231,7 @@ func (handler *OrderHandler) GetAllUserOrderWait(c echo.Context) error {
 }
 
 func (handler *OrderHandler) GetDeliveryBatchWithRegion(c echo.Context) error {
-	adminIdLogin := middlewares.ExtractTokenUserId(c)
+	adminIdLogin := middlewares.ExtractTokenAdminId(c)
 	if adminIdLogin == 0 {
 		return c.JSON(http.StatusUnauthorized, responses.WebResponse("Silahkan login terlebih dahulu", nil))
 	}
@@ -211,7 +247,7 @@ func (handler *OrderHandler) GetDeliveryBatchWithRegion(c echo.Context) error {
 }
 
 func (handler *OrderHandler) GetUserOrderNames(c echo.Context) error {
-	adminIdLogin := middlewares.ExtractTokenUserId(c)
+	adminIdLogin := middlewares.ExtractTokenAdminId(c)
 	if adminIdLogin == 0 {
 		return c.JSON(http.StatusUnauthorized, responses.WebResponse("Silahkan login terlebih dahulu", nil))
 	}
@@ -233,7 +269,7 @@ func (handler *OrderHandler) GetUserOrderNames(c echo.Context) error {
 }
 
 func (handler *OrderHandler) GetOrderByNameUser(c echo.Context) error {
-	adminIdLogin := middlewares.ExtractTokenUserId(c)
+	adminIdLogin := middlewares.ExtractTokenAdminId(c)
 	if adminIdLogin == 0 {
 		return c.JSON(http.StatusUnauthorized, responses.WebResponse("Silahkan login terlebih dahulu", nil))
 	}
@@ -254,13 +290,16 @@ func (handler *OrderHandler) GetOrderByNameUser(c echo.Context) error {
 		return c.JSON(http.StatusInternalServerError, responses.WebResponse(err.Error(), nil))
 	}
 
-	response := CoreToGroupedAdminOrderResponse(userOrders, batch, code, handler.orderService.GetFoto)
+	getFoto := func(batch, code string, userId int) (*order.PhotoOrder, error) {
+		return handler.orderService.GetFotoForAdmin(adminIdLogin, batch, code, userId)
+	}
+	response := CoreToGroupedAdminOrderResponse(userOrders, batch, code, getFoto)
 
 	return c.JSON(http.StatusOK, responses.WebResponse("Berhasil mendapatkan user order", response))
 }
 
 func (handler *OrderHandler) UpdateEstimationForOrders(c echo.Context) error {
-    adminIdLogin := middlewares.ExtractTokenUserId(c)
+    adminIdLogin := middlewares.ExtractTokenAdminId(c)
     if adminIdLogin == 0 {
         return c.JSON(http.StatusUnauthorized, responses.WebResponse("Silahkan login terlebih dahulu", nil))
     }
@@ -291,7 +330,7 @@ func (handler *OrderHandler) UpdateEstimationForOrders(c echo.Context) error {
 }
 
 func (handler *OrderHandler) UpdateOrderStatus(c echo.Context) error {
-    adminIdLogin := middlewares.ExtractTokenUserId(c)
+    adminIdLogin := middlewares.ExtractTokenAdminId(c)
     if adminIdLogin == 0 {
         return c.JSON(http.StatusUnauthorized, responses.WebResponse("Silahkan login terlebih dahulu", nil))
     }
@@ -317,7 +356,7 @@ func (handler *OrderHandler) UpdateOrderStatus(c echo.Context) error {
 }
 
 func (handler *OrderHandler) UploadFotoPacked(c echo.Context) error {
-	adminIdLogin := middlewares.ExtractTokenUserId(c)
+	adminIdLogin := middlewares.ExtractTokenAdminId(c)
 	if adminIdLogin == 0 {
 		return c.JSON(http.StatusUnauthorized, responses.WebResponse("Silahkan login terlebih dahulu", nil))
 	}
@@ -334,16 +373,17 @@ func (handler *OrderHandler) UploadFotoPacked(c echo.Context) error {
 	}
 
 	photoCore := RequestToPhotoOrder(uploadRequest)
-	errUpload := handler.orderService.UploadFotoPacked(adminIdLogin, photoCore, fileHeaderPacked)
+	photoId, errUpload := handler.orderService.UploadFotoPacked(adminIdLogin, photoCore, fileHeaderPacked)
 	if errUpload != nil {
 		return c.JSON(http.StatusInternalServerError, responses.WebResponse(errUpload.Error(), nil))
 	}
+	middlewares.SetAuditEntity(c, strconv.Itoa(int(photoId)))
 
 	return c.JSON(http.StatusOK, responses.WebResponse("Berhasil mengunggah foto", nil))
 }
 
 func (handler *OrderHandler) UploadFotoReceived(c echo.Context) error {
-	adminIdLogin := middlewares.ExtractTokenUserId(c)
+	adminIdLogin := middlewares.ExtractTokenAdminId(c)
 	if adminIdLogin == 0 {
 		return c.JSON(http.StatusUnauthorized, responses.WebResponse("Silahkan login terlebih dahulu", nil))
 	}
@@ -370,6 +410,11 @@ func (handler *OrderHandler) UploadFotoReceived(c echo.Context) error {
 }
 
 func (handler *OrderHandler) GenerateCSVByBatch(c echo.Context) error {
+	adminIdLogin := middlewares.ExtractTokenAdminId(c)
+	if adminIdLogin == 0 {
+		return c.JSON(http.StatusUnauthorized, responses.WebResponse("Silahkan login terlebih dahulu", nil))
+	}
+
 	batch := c.QueryParam("batch")
 	if batch == "" {
 		return c.JSON(http.StatusBadRequest, responses.WebResponse("Batch pengiriman tidak boleh kosong", nil))
@@ -377,7 +422,7 @@ func (handler *OrderHandler) GenerateCSVByBatch(c echo.Context) error {
 
 	filePath := "batch_pengiriman_" + batch + ".csv"
 	
-	err := handler.orderService.GenerateCSVByBatch(batch, filePath)
+	err := handler.orderService.GenerateCSVByBatch(adminIdLogin, batch, filePath)
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, responses.WebResponse(err.Error(), nil))
 	}
@@ -388,7 +433,7 @@ func (handler *OrderHandler) GenerateCSVByBatch(c echo.Context) error {
 }
 
 func (handler *OrderHandler) SearchOrder(c echo.Context) error {
-	adminIdLogin := middlewares.ExtractTokenUserId(c)
+	adminIdLogin := middlewares.ExtractTokenAdminId(c)
 	if adminIdLogin == 0 {
 		return c.JSON(http.StatusUnauthorized, responses.WebResponse("Silahkan login terlebih dahulu", nil))
 	}
@@ -409,7 +454,7 @@ func (handler *OrderHandler) SearchOrder(c echo.Context) error {
 }
 
 func (handler *OrderHandler) UpdateOrderById(c echo.Context) error {
-	adminIdLogin := middlewares.ExtractTokenUserId(c)
+	adminIdLogin := middlewares.ExtractTokenAdminId(c)
 	if adminIdLogin == 0 {
 		return c.JSON(http.StatusUnauthorized, responses.WebResponse("Silahkan login terlebih dahulu", nil))
 	}
@@ -435,7 +480,7 @@ func (handler *OrderHandler) UpdateOrderById(c echo.Context) error {
 }
 
 func (handler *OrderHandler) GetOrderSStats(c echo.Context) error {
-	adminIdLogin := middlewares.ExtractTokenUserId(c)
+	adminIdLogin := middlewares.ExtractTokenAdminId(c)
 	if adminIdLogin == 0 {
 		return c.JSON(http.StatusUnauthorized, responses.WebResponse("Silahkan login terlebih dahulu", nil))
 	}
@@ -456,4 +501,428 @@ func (handler *OrderHandler) GetOrderSStats(c echo.Context) error {
 	}
 
 	return c.JSON(http.StatusOK, responses.WebResponse("Berhasil mendapatkan statistik", orderStatsResponses))
-}
\ No newline at end of file
+}
+
+func (handler *OrderHandler) ShipDeliveryBatch(c echo.Context) error {
+	adminIdLogin := middlewares.ExtractTokenAdminId(c)
+	if adminIdLogin == 0 {
+		return c.JSON(http.StatusUnauthorized, responses.WebResponse("Silahkan login terlebih dahulu", nil))
+	}
+
+	batch := c.Param("batch_id")
+	if batch == "" {
+		return c.JSON(http.StatusBadRequest, responses.WebResponse("batch tidak boleh kosong", nil))
+	}
+
+	estimations, err := handler.orderService.ShipDeliveryBatch(adminIdLogin, batch)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, responses.WebResponse(err.Error(), nil))
+	}
+
+	var estimationResponses []BatchEstimationResponse
+	for _, estimation := range estimations {
+		estimationResponses = append(estimationResponses, CoreToResponseBatchEstimation(estimation))
+	}
+
+	return c.JSON(http.StatusOK, responses.WebResponse("Batch berhasil ditandai dikirim", estimationResponses))
+}
+
+func (handler *OrderHandler) GenerateManifestByBatch(c echo.Context) error {
+	adminIdLogin := middlewares.ExtractTokenAdminId(c)
+	if adminIdLogin == 0 {
+		return c.JSON(http.StatusUnauthorized, responses.WebResponse("Silahkan login terlebih dahulu", nil))
+	}
+
+	batch := c.QueryParam("batch")
+	code := c.QueryParam("code")
+	if code == "" || batch == "" {
+		return c.JSON(http.StatusBadRequest, responses.WebResponse("Code dan batch tidak boleh kosong", nil))
+	}
+
+	format := c.QueryParam("format")
+	if format == "" {
+		format = "pdf"
+	}
+
+	filePath := "manifest_" + batch + "_" + code + "." + format
+
+	err := handler.orderService.GenerateManifestByBatch(adminIdLogin, batch, code, format, filePath)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, responses.WebResponse(err.Error(), nil))
+	}
+
+	contentType := "application/pdf"
+	if format == "html" {
+		contentType = echo.MIMETextHTMLCharsetUTF8
+	}
+
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%s", filePath))
+	c.Response().Header().Set(echo.HeaderContentType, contentType)
+	return c.File(filePath)
+}
+
+func (handler *OrderHandler) GenerateOrderLabel(c echo.Context) error {
+	adminIdLogin := middlewares.ExtractTokenAdminId(c)
+	if adminIdLogin == 0 {
+		return c.JSON(http.StatusUnauthorized, responses.WebResponse("Silahkan login terlebih dahulu", nil))
+	}
+
+	orderId, errParse := strconv.ParseUint(c.Param("order_id"), 10, 64)
+	if errParse != nil {
+		return c.JSON(http.StatusBadRequest, responses.WebResponse("ID order tidak valid", nil))
+	}
+
+	format := c.QueryParam("format")
+	if format == "" {
+		format = "png"
+	}
+
+	filePath := fmt.Sprintf("label_order_%d.%s", orderId, format)
+
+	err := handler.orderService.GenerateOrderLabel(adminIdLogin, uint(orderId), format, filePath)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, responses.WebResponse(err.Error(), nil))
+	}
+
+	return sendLabelFile(c, filePath, format)
+}
+
+func (handler *OrderHandler) GenerateBoxLabel(c echo.Context) error {
+	adminIdLogin := middlewares.ExtractTokenAdminId(c)
+	if adminIdLogin == 0 {
+		return c.JSON(http.StatusUnauthorized, responses.WebResponse("Silahkan login terlebih dahulu", nil))
+	}
+
+	batch := c.QueryParam("batch")
+	code := c.QueryParam("code")
+	if code == "" || batch == "" {
+		return c.JSON(http.StatusBadRequest, responses.WebResponse("Code dan batch tidak boleh kosong", nil))
+	}
+
+	format := c.QueryParam("format")
+	if format == "" {
+		format = "png"
+	}
+
+	filePath := "label_kotak_" + batch + "_" + code + "." + format
+
+	err := handler.orderService.GenerateBoxLabel(adminIdLogin, batch, code, format, filePath)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, responses.WebResponse(err.Error(), nil))
+	}
+
+	return sendLabelFile(c, filePath, format)
+}
+
+func (handler *OrderHandler) GenerateBatchLabels(c echo.Context) error {
+	adminIdLogin := middlewares.ExtractTokenAdminId(c)
+	if adminIdLogin == 0 {
+		return c.JSON(http.StatusUnauthorized, responses.WebResponse("Silahkan login terlebih dahulu", nil))
+	}
+
+	batch := c.QueryParam("batch")
+	code := c.QueryParam("code")
+	if code == "" || batch == "" {
+		return c.JSON(http.StatusBadRequest, responses.WebResponse("Code dan batch tidak boleh kosong", nil))
+	}
+
+	size := c.QueryParam("size")
+	if size == "" {
+		size = "a4"
+	}
+
+	filePath := "label_" + batch + "_" + code + "_" + size + ".pdf"
+
+	err := handler.orderService.GenerateBatchLabels(adminIdLogin, batch, code, size, filePath)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, responses.WebResponse(err.Error(), nil))
+	}
+
+	return sendLabelFile(c, filePath, "pdf")
+}
+
+func (handler *OrderHandler) ScanLabel(c echo.Context) error {
+	adminIdLogin := middlewares.ExtractTokenAdminId(c)
+	if adminIdLogin == 0 {
+		return c.JSON(http.StatusUnauthorized, responses.WebResponse("Silahkan login terlebih dahulu", nil))
+	}
+
+	code := c.QueryParam("code")
+
+	userOrders, err := handler.orderService.ScanLabel(adminIdLogin, code)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, responses.WebResponse(err.Error(), nil))
+	}
+
+	var orderResponses []OrderResponseById
+	for _, userOrder := range userOrders {
+		orderResponses = append(orderResponses, CoreToResponseUserOrderById(userOrder))
+	}
+
+	return c.JSON(http.StatusOK, responses.WebResponse("Berhasil memindai label", orderResponses))
+}
+
+func sendLabelFile(c echo.Context, filePath, format string) error {
+	contentType := "application/pdf"
+	if format == "png" {
+		contentType = "image/png"
+	}
+
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%s", filePath))
+	c.Response().Header().Set(echo.HeaderContentType, contentType)
+	return c.File(filePath)
+}
+
+func (handler *OrderHandler) MoveShelfLocation(c echo.Context) error {
+	adminIdLogin := middlewares.ExtractTokenAdminId(c)
+	if adminIdLogin == 0 {
+		return c.JSON(http.StatusUnauthorized, responses.WebResponse("Silahkan login terlebih dahulu", nil))
+	}
+
+	userOrderId, errParse := strconv.ParseUint(c.Param("order_id"), 10, 64)
+	if errParse != nil {
+		return c.JSON(http.StatusBadRequest, responses.WebResponse("ID order tidak valid", nil))
+	}
+
+	var req ShelfLocationRequest
+	errBind := c.Bind(&req)
+	if errBind != nil {
+		return c.JSON(http.StatusBadRequest, responses.WebResponse("error bind data. data lokasi not valid", nil))
+	}
+
+	err := handler.orderService.MoveShelfLocation(adminIdLogin, uint(userOrderId), req.ShelfLocation)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, responses.WebResponse(err.Error(), nil))
+	}
+
+	return c.JSON(http.StatusOK, responses.WebResponse("Lokasi rak berhasil diperbarui", nil))
+}
+
+func (handler *OrderHandler) GetShelfLocationHistory(c echo.Context) error {
+	adminIdLogin := middlewares.ExtractTokenAdminId(c)
+	if adminIdLogin == 0 {
+		return c.JSON(http.StatusUnauthorized, responses.WebResponse("Silahkan login terlebih dahulu", nil))
+	}
+
+	userOrderId, errParse := strconv.ParseUint(c.Param("order_id"), 10, 64)
+	if errParse != nil {
+		return c.JSON(http.StatusBadRequest, responses.WebResponse("ID order tidak valid", nil))
+	}
+
+	histories, err := handler.orderService.GetShelfLocationHistory(adminIdLogin, uint(userOrderId))
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, responses.WebResponse(err.Error(), nil))
+	}
+
+	var historyResponses []ShelfLocationHistoryResponse
+	for _, history := range histories {
+		historyResponses = append(historyResponses, CoreToResponseShelfLocationHistory(history))
+	}
+
+	return c.JSON(http.StatusOK, responses.WebResponse("Berhasil mendapatkan riwayat lokasi rak", historyResponses))
+}
+
+func (handler *OrderHandler) FindOrderLocation(c echo.Context) error {
+	adminIdLogin := middlewares.ExtractTokenAdminId(c)
+	if adminIdLogin == 0 {
+		return c.JSON(http.StatusUnauthorized, responses.WebResponse("Silahkan login terlebih dahulu", nil))
+	}
+
+	trackingNumber := c.QueryParam("tracking_number")
+
+	userOrder, err := handler.orderService.FindOrderLocation(adminIdLogin, trackingNumber)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, responses.WebResponse(err.Error(), nil))
+	}
+
+	return c.JSON(http.StatusOK, responses.WebResponse("Berhasil menemukan lokasi barang", CoreToResponseOrderLocation(*userOrder)))
+}
+
+func (handler *OrderHandler) GetPickList(c echo.Context) error {
+	adminIdLogin := middlewares.ExtractTokenAdminId(c)
+	if adminIdLogin == 0 {
+		return c.JSON(http.StatusUnauthorized, responses.WebResponse("Silahkan login terlebih dahulu", nil))
+	}
+
+	batch := c.QueryParam("batch")
+	if batch == "" {
+		return c.JSON(http.StatusBadRequest, responses.WebResponse("batch tidak boleh kosong", nil))
+	}
+	code := c.QueryParam("code")
+
+	userOrders, err := handler.orderService.GetPickList(adminIdLogin, batch, code)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, responses.WebResponse(err.Error(), nil))
+	}
+
+	var pickListResponses []OrderLocationResponse
+	for _, userOrder := range userOrders {
+		pickListResponses = append(pickListResponses, CoreToResponseOrderLocation(userOrder))
+	}
+
+	return c.JSON(http.StatusOK, responses.WebResponse("Berhasil mendapatkan daftar ambil barang", pickListResponses))
+}
+
+func (handler *OrderHandler) GetStorageAging(c echo.Context) error {
+	adminIdLogin := middlewares.ExtractTokenAdminId(c)
+	if adminIdLogin == 0 {
+		return c.JSON(http.StatusUnauthorized, responses.WebResponse("Silahkan login terlebih dahulu", nil))
+	}
+
+	minDays := 0
+	if minDaysStr := c.QueryParam("min_days"); minDaysStr != "" {
+		parsed, err := strconv.Atoi(minDaysStr)
+		if err != nil || parsed < 0 {
+			return c.JSON(http.StatusBadRequest, responses.WebResponse("min_days tidak valid", nil))
+		}
+		minDays = parsed
+	}
+
+	agings, err := handler.orderService.GetStorageAging(adminIdLogin, minDays)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, responses.WebResponse(err.Error(), nil))
+	}
+
+	var agingResponses []StorageAgingResponse
+	for _, aging := range agings {
+		agingResponses = append(agingResponses, CoreToResponseStorageAging(aging))
+	}
+
+	return c.JSON(http.StatusOK, responses.WebResponse("Berhasil mendapatkan laporan lama penyimpanan", agingResponses))
+}
+
+func (handler *OrderHandler) UploadConditionPhotos(c echo.Context) error {
+	adminIdLogin := middlewares.ExtractTokenAdminId(c)
+	if adminIdLogin == 0 {
+		return c.JSON(http.StatusUnauthorized, responses.WebResponse("Silahkan login terlebih dahulu", nil))
+	}
+
+	orderId, err := strconv.Atoi(c.Param("order_id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, responses.WebResponse("ID order tidak valid", nil))
+	}
+
+	form, err := c.MultipartForm()
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, responses.WebResponse("error retrieving the file", nil))
+	}
+
+	category := c.FormValue("category")
+	errUpload := handler.orderService.UploadConditionPhotos(adminIdLogin, uint(orderId), category, form.File["photo"])
+	if errUpload != nil {
+		return c.JSON(http.StatusInternalServerError, responses.WebResponse(errUpload.Error(), nil))
+	}
+
+	return c.JSON(http.StatusOK, responses.WebResponse("Berhasil upload foto kondisi barang", nil))
+}
+
+func (handler *OrderHandler) ReplaceFotoPacked(c echo.Context) error {
+	adminIdLogin := middlewares.ExtractTokenAdminId(c)
+	if adminIdLogin == 0 {
+		return c.JSON(http.StatusUnauthorized, responses.WebResponse("Silahkan login terlebih dahulu", nil))
+	}
+
+	idFoto, err := strconv.Atoi(c.Param("id_foto"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, responses.WebResponse("error parsing foto id", nil))
+	}
+
+	fileHeaderPacked, err := c.FormFile("photo_packed")
+	if err != nil && err != http.ErrMissingFile {
+		return c.JSON(http.StatusBadRequest, responses.WebResponse("error retrieving the file", nil))
+	}
+
+	errUpload := handler.orderService.ReplaceFotoPacked(adminIdLogin, uint(idFoto), fileHeaderPacked)
+	if errUpload != nil {
+		return c.JSON(http.StatusInternalServerError, responses.WebResponse(errUpload.Error(), nil))
+	}
+
+	return c.JSON(http.StatusOK, responses.WebResponse("Berhasil mengganti foto", nil))
+}
+
+func (handler *OrderHandler) DeletePhotoOrder(c echo.Context) error {
+	adminIdLogin := middlewares.ExtractTokenAdminId(c)
+	if adminIdLogin == 0 {
+		return c.JSON(http.StatusUnauthorized, responses.WebResponse("Silahkan login terlebih dahulu", nil))
+	}
+
+	idFoto, err := strconv.Atoi(c.Param("id_foto"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, responses.WebResponse("error parsing foto id", nil))
+	}
+
+	errDelete := handler.orderService.DeletePhotoOrder(adminIdLogin, uint(idFoto))
+	if errDelete != nil {
+		return c.JSON(http.StatusInternalServerError, responses.WebResponse(errDelete.Error(), nil))
+	}
+
+	return c.JSON(http.StatusOK, responses.WebResponse("Berhasil menghapus foto", nil))
+}
+
+func (handler *OrderHandler) DeleteConditionPhoto(c echo.Context) error {
+	adminIdLogin := middlewares.ExtractTokenAdminId(c)
+	if adminIdLogin == 0 {
+		return c.JSON(http.StatusUnauthorized, responses.WebResponse("Silahkan login terlebih dahulu", nil))
+	}
+
+	orderId, err := strconv.Atoi(c.Param("order_id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, responses.WebResponse("ID order tidak valid", nil))
+	}
+
+	photoId, err := strconv.Atoi(c.Param("photo_id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, responses.WebResponse("error parsing foto id", nil))
+	}
+
+	errDelete := handler.orderService.DeleteConditionPhoto(adminIdLogin, uint(orderId), uint(photoId))
+	if errDelete != nil {
+		return c.JSON(http.StatusInternalServerError, responses.WebResponse(errDelete.Error(), nil))
+	}
+
+	return c.JSON(http.StatusOK, responses.WebResponse("Berhasil menghapus foto kondisi barang", nil))
+}
+
+func (handler *OrderHandler) GetConditionPhotos(c echo.Context) error {
+	adminIdLogin := middlewares.ExtractTokenAdminId(c)
+	if adminIdLogin == 0 {
+		return c.JSON(http.StatusUnauthorized, responses.WebResponse("Silahkan login terlebih dahulu", nil))
+	}
+
+	orderId, err := strconv.Atoi(c.Param("order_id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, responses.WebResponse("ID order tidak valid", nil))
+	}
+
+	photos, err := handler.orderService.GetConditionPhotosForAdmin(adminIdLogin, uint(orderId))
+	if err != nil {
+		return c.JSON(http.StatusForbidden, responses.WebResponse(err.Error(), nil))
+	}
+
+	return c.JSON(http.StatusOK, responses.WebResponse("Berhasil mendapatkan foto kondisi barang", CoreToResponseConditionPhotos(photos)))
+}
+
+func (handler *OrderHandler) RecordPickup(c echo.Context) error {
+	adminIdLogin := middlewares.ExtractTokenAdminId(c)
+	if adminIdLogin == 0 {
+		return c.JSON(http.StatusUnauthorized, responses.WebResponse("Silahkan login terlebih dahulu", nil))
+	}
+
+	orderId, err := strconv.Atoi(c.Param("order_id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, responses.WebResponse("ID order tidak valid", nil))
+	}
+
+	fileHeader, err := c.FormFile("photo")
+	if err != nil && err != http.ErrMissingFile {
+		return c.JSON(http.StatusBadRequest, responses.WebResponse("error retrieving the file", nil))
+	}
+
+	recipientName := c.FormValue("recipient_name")
+	errPickup := handler.orderService.RecordPickup(adminIdLogin, uint(orderId), recipientName, fileHeader)
+	if errPickup != nil {
+		return c.JSON(http.StatusInternalServerError, responses.WebResponse(errPickup.Error(), nil))
+	}
+
+	return c.JSON(http.StatusOK, responses.WebResponse("Berhasil mencatat pengambilan barang", nil))
+}
@@ -5,6 +5,7 @@ import (
 	"jastip-jakarta/features/order"
 	"math/rand"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -21,6 +22,11 @@ type OrderDetailRequest struct {
 	Status        string  `json:"status"`
 	WeightItem    float64 `json:"weight_item"`
 	DeliveryBatch string  `json:"delivery_batch"`
+	ShelfLocation string  `json:"shelf_location"`
+}
+
+type ShelfLocationRequest struct {
+	ShelfLocation string `json:"shelf_location"`
 }
 
 type UploadFotoRequest struct {
@@ -97,6 +103,7 @@ func RequestToOrderDetail(input OrderDetailRequest, userOrder order.UserOrder) o
 		WeightItem:           input.WeightItem,
 		TrackingNumberJastip: generateJastipResi(userOrder.WhatsAppNumber, userOrder.TrackingNumber),
 		DeliveryBatchID:      &deliveryBatch,
+		ShelfLocation:        strings.ToUpper(strings.TrimSpace(input.ShelfLocation)),
 	}
 }
 
@@ -63,21 +63,38 @@ type UserOrderProcessResponse struct {
 	TrackingNumber       string `json:"tracking_number"`
 	OnlineStore          string `json:"online_store"`
 	WeightItem           int    `json:"weight_item"`
+	StorageFee           int    `json:"storage_fee"`
 }
 
 type OrderResponseById struct {
-	ID                   uint   `json:"order_id"`
-	Status               string `json:"status"`
-	Name                 string `json:"name"`
-	ItemName             string `json:"item_name"`
-	TrackingNumber       string `json:"tracking_number"`
-	TrackingNumberJastip string `json:"tracking_number_jastip"`
-	OnlineStore          string `json:"online_store"`
-	Code                 string `json:"code"`
-	Region               string `json:"region"`
-	FullAddress          string `json:"full_address"`
-	WhatsappNumber       int    `json:"whatsapp_number"`
-	WeightItem           int    `json:"weight_item"`
+	ID                   uint                     `json:"order_id"`
+	Status               string                   `json:"status"`
+	Name                 string                   `json:"name"`
+	ItemName             string                   `json:"item_name"`
+	TrackingNumber       string                   `json:"tracking_number"`
+	TrackingNumberJastip string                   `json:"tracking_number_jastip"`
+	OnlineStore          string                   `json:"online_store"`
+	Code                 string                   `json:"code"`
+	Region               string                   `json:"region"`
+	FullAddress          string                   `json:"full_address"`
+	WhatsappNumber       int                      `json:"whatsapp_number"`
+	WeightItem           int                      `json:"weight_item"`
+	ConditionPhotos      []ConditionPhotoResponse `json:"condition_photos"`
+	Pickup               *PickupResponse          `json:"pickup,omitempty"`
+}
+
+type PickupResponse struct {
+	RecipientName string `json:"recipient_name"`
+	Photo         string `json:"photo"`
+	PickedUpAt    string `json:"picked_up_at"`
+}
+
+type ConditionPhotoResponse struct {
+	ID         uint   `json:"id_foto"`
+	Category   string `json:"category"`
+	Photo      string `json:"photo"`
+	Thumbnail  string `json:"thumbnail"`
+	UploadedAt string `json:"uploaded_at"`
 }
 
 type DeliveryBatchWithRegionResponse struct {
@@ -112,6 +129,102 @@ type RegionBatchStats struct {
 	TotalPrice   int     `json:"total_harga_dalam_batch"`
 }
 
+type OrderLocationResponse struct {
+	ID                   uint   `json:"order_id"`
+	Name                 string `json:"name"`
+	ItemName             string `json:"item_name"`
+	Status               string `json:"status"`
+	TrackingNumber       string `json:"tracking_number"`
+	TrackingNumberJastip string `json:"tracking_number_jastip"`
+	Code                 string `json:"code"`
+	DeliveryBatch        string `json:"delivery_batch"`
+	ShelfLocation        string `json:"shelf_location"`
+}
+
+type ShelfLocationHistoryResponse struct {
+	FromLocation string `json:"from_location"`
+	ToLocation   string `json:"to_location"`
+	AdminID      uint   `json:"admin_id"`
+	MovedAt      string `json:"moved_at"`
+}
+
+func CoreToResponseOrderLocation(data order.UserOrder) OrderLocationResponse {
+	deliveryBatch := ""
+	if data.OrderDetails.DeliveryBatchID != nil {
+		deliveryBatch = *data.OrderDetails.DeliveryBatchID
+	}
+
+	return OrderLocationResponse{
+		ID:                   data.ID,
+		Name:                 data.User.Name,
+		ItemName:             data.ItemName,
+		Status:               data.OrderDetails.Status,
+		TrackingNumber:       data.TrackingNumber,
+		TrackingNumberJastip: data.OrderDetails.TrackingNumberJastip,
+		Code:                 data.Region.ID,
+		DeliveryBatch:        deliveryBatch,
+		ShelfLocation:        data.OrderDetails.ShelfLocation,
+	}
+}
+
+func CoreToResponseShelfLocationHistory(data order.ShelfLocationHistory) ShelfLocationHistoryResponse {
+	return ShelfLocationHistoryResponse{
+		FromLocation: data.FromLocation,
+		ToLocation:   data.ToLocation,
+		AdminID:      data.AdminID,
+		MovedAt:      data.CreatedAt.Format("02/01/2006 15:04"),
+	}
+}
+
+type StorageAgingResponse struct {
+	ID                   uint   `json:"order_id"`
+	Name                 string `json:"name"`
+	ItemName             string `json:"item_name"`
+	TrackingNumberJastip string `json:"tracking_number_jastip"`
+	Code                 string `json:"code"`
+	ShelfLocation        string `json:"shelf_location"`
+	ReceivedAt           string `json:"received_at"`
+	DaysStored           int    `json:"days_stored"`
+	ChargeableDays       int    `json:"chargeable_days"`
+	StorageFee           int    `json:"storage_fee"`
+}
+
+func CoreToResponseStorageAging(data order.StorageAging) StorageAgingResponse {
+	receivedAt := ""
+	if data.UserOrder.OrderDetails.ReceivedAt != nil {
+		receivedAt = data.UserOrder.OrderDetails.ReceivedAt.Format("02/01/2006 15:04")
+	}
+
+	return StorageAgingResponse{
+		ID:                   data.UserOrder.ID,
+		Name:                 data.UserOrder.User.Name,
+		ItemName:             data.UserOrder.ItemName,
+		TrackingNumberJastip: data.UserOrder.OrderDetails.TrackingNumberJastip,
+		Code:                 data.UserOrder.Region.ID,
+		ShelfLocation:        data.UserOrder.OrderDetails.ShelfLocation,
+		ReceivedAt:           receivedAt,
+		DaysStored:           data.DaysStored,
+		ChargeableDays:       data.ChargeableDays,
+		StorageFee:           data.StorageFee,
+	}
+}
+
+type BatchEstimationResponse struct {
+	Code         string `json:"code"`
+	Region       string `json:"region"`
+	EstimasiAwal string `json:"estimasi_awal"`
+	Estimasi     string `json:"estimasi"`
+}
+
+func CoreToResponseBatchEstimation(data order.BatchEstimation) BatchEstimationResponse {
+	return BatchEstimationResponse{
+		Code:         data.RegionCode,
+		Region:       data.Region,
+		EstimasiAwal: time.FormatDateToIndonesian(data.EarliestDelivery),
+		Estimasi:     time.FormatDateToIndonesian(data.EstimatedDelivery),
+	}
+}
+
 func CoreToResponseRegionBatchStats(data order.RegionBatchStats) RegionBatchStats {
 	return RegionBatchStats{
 		RegionCode:   data.RegionCode,
@@ -137,7 +250,34 @@ func CoreToResponseUserOrderById(data order.UserOrder) OrderResponseById {
 		Name:                 data.User.Name,
 		Status:               data.OrderDetails.Status,
 		TrackingNumberJastip: data.OrderDetails.TrackingNumberJastip,
+		ConditionPhotos:      CoreToResponseConditionPhotos(data.ConditionPhotos),
+		Pickup:               CoreToResponsePickup(data.Pickup),
+	}
+}
+
+func CoreToResponsePickup(data *order.OrderPickup) *PickupResponse {
+	if data == nil {
+		return nil
+	}
+	return &PickupResponse{
+		RecipientName: data.RecipientName,
+		Photo:         data.Photo,
+		PickedUpAt:    data.PickedUpAt.Format("02/01/2006 15:04"),
+	}
+}
+
+func CoreToResponseConditionPhotos(data []order.ConditionPhoto) []ConditionPhotoResponse {
+	photos := []ConditionPhotoResponse{}
+	for _, photo := range data {
+		photos = append(photos, ConditionPhotoResponse{
+			ID:         photo.ID,
+			Category:   photo.Category,
+			Photo:      photo.Photo,
+			Thumbnail:  photo.Thumbnail,
+			UploadedAt: photo.CreatedAt.Format("02/01/2006 15:04"),
+		})
 	}
+	return photos
 }
 
 func CoreToResponseUserOrderWait(data order.UserOrder) UserOrderWaitResponse {
@@ -303,6 +443,7 @@ func CoreToUserOrderProcessResponse(data order.UserOrder) UserOrderProcessRespon
 		TrackingNumber:       data.TrackingNumber,
 		OnlineStore:          data.OnlineStore,
 		WeightItem:           int(data.OrderDetails.WeightItem),
+		StorageFee:           data.OrderDetails.StorageFee,
 	}
 }
 
@@ -321,7 +462,8 @@ func hitungTotalBerat(data []order.UserOrder) int {
 func hitungTotalHarga(data []order.UserOrder) int {
 	totalHarga := 0
 	for _, pesanan := range data {
-		totalHarga += int(pesanan.OrderDetails.WeightItem) * dapatkanHargaPerBerat(pesanan.Region.Price)
+		totalHarga += order.ItemPrice(pesanan.OrderDetails.WeightItem, dapatkanHargaPerBerat(pesanan.Region.Price))
+		totalHarga += pesanan.OrderDetails.StorageFee
 	}
 	return totalHarga
 }
@@ -344,7 +486,8 @@ func CoreToGroupedAdminOrderResponse(data []order.UserOrder, batch string, code
 	for _, userOrder := range data {
 		orders = append(orders, CoreToUserOrderProcessResponse(userOrder))
 		totalWeight += int(userOrder.OrderDetails.WeightItem)
-		totalPrice += int(userOrder.OrderDetails.WeightItem) * userOrder.Region.Price
+		totalPrice += order.ItemPrice(userOrder.OrderDetails.WeightItem, userOrder.Region.Price)
+		totalPrice += userOrder.OrderDetails.StorageFee
 		customers = Customer{Name: userOrder.User.Name, ID: userOrder.User.ID}
 	}
 
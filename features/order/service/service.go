@@ -2,21 +2,37 @@ package service
 
 import (
 	"errors"
+	"fmt"
+	"jastip-jakarta/app/config"
 	"jastip-jakarta/features/admin"
 	"jastip-jakarta/features/order"
+	"jastip-jakarta/features/user"
+	"jastip-jakarta/utils/label"
+	"jastip-jakarta/utils/manifest"
+	"jastip-jakarta/utils/storage"
+	timejkt "jastip-jakarta/utils/time"
 	"mime/multipart"
+	"strings"
 	"time"
 )
 
 type orderService struct {
 	orderData    order.OrderDataInterface
 	adminService admin.AdminServiceInterface
+	userService  user.UserServiceInterface
+	manifest     manifest.ManifestGeneratorInterface
+	label        label.LabelGeneratorInterface
+	uploader     storage.UploaderInterface
 }
 
-func New(repo order.OrderDataInterface, adminService admin.AdminServiceInterface) order.OrderServiceInterface {
+func New(repo order.OrderDataInterface, adminService admin.AdminServiceInterface, userService user.UserServiceInterface, manifestGenerator manifest.ManifestGeneratorInterface, labelGenerator label.LabelGeneratorInterface, uploader storage.UploaderInterface) order.OrderServiceInterface {
 	return &orderService{
 		orderData:    repo,
 		adminService: adminService,
+		userService:  userService,
+		manifest:     manifestGenerator,
+		label:        labelGenerator,
+		uploader:     uploader,
 	}
 }
 
@@ -38,7 +54,15 @@ func (o *orderService) CreateUserOrder(userIdLogin int, inputOrder order.UserOrd
 		return errors.New("kode wilayah harus diisi")
 	}
 
-	_, err := o.adminService.GettByIdRegion(inputOrder.RegionCode)
+	userCheck, err := o.userService.GetById(userIdLogin)
+	if err != nil {
+		return err
+	}
+	if userCheck.EmailVerifiedAt == nil || userCheck.PhoneVerifiedAt == nil {
+		return errors.New("verifikasi email dan nomor WhatsApp terlebih dahulu sebelum membuat order")
+	}
+
+	_, err = o.adminService.GettByIdRegion(inputOrder.RegionCode)
 	if err != nil {
 		return err
 	}
@@ -82,14 +106,119 @@ func (o *orderService) GetById(IdOrder uint) (*order.UserOrder, error) {
 	return result, err
 }
 
-// CreateOrderDetail implements order.OrderServiceInterface.
-func (o *orderService) CreateOrderDetail(adminIdLogin int, userOrderId uint, inputOrder order.OrderDetail) error {
-	adminCheck, err := o.adminService.GetById(adminIdLogin)
-	if err != nil || adminCheck == nil {
-		return errors.New("anda bukan admin")
+// GetOrderForUser implements order.OrderServiceInterface.
+// Order milik user lain dianggap tidak ada agar ID order tidak bisa ditebak.
+func (o *orderService) GetOrderForUser(userIdLogin int, userOrderId uint) (*order.UserOrder, error) {
+	userOrder, err := o.orderData.SelectById(userOrderId)
+	if err != nil || userIdLogin == 0 || uint(userIdLogin) != userOrder.UserID {
+		return nil, errors.New("order tidak ditemukan")
+	}
+	return userOrder, nil
+}
+
+// GetOrderForAdmin implements order.OrderServiceInterface.
+func (o *orderService) GetOrderForAdmin(adminIdLogin int, userOrderId uint) (*order.UserOrder, error) {
+	return o.orderInScope(adminIdLogin, userOrderId)
+}
+
+// regionScope berisi kode wilayah yang boleh diakses seorang admin.
+type regionScope struct {
+	all   bool
+	codes map[string]bool
+}
+
+func (s regionScope) allows(code string) bool {
+	return s.all || s.codes[code]
+}
+
+// regionScopeOf mengambil wilayah yang menjadi tanggung jawab admin, yaitu wilayah dengan
+// RegionCode.AdminID miliknya. Admin dengan permission region.all boleh mengakses semua wilayah.
+func (o *orderService) regionScopeOf(adminIdLogin int) (regionScope, error) {
+	all, err := o.adminService.HasPermission(adminIdLogin, admin.PermissionRegionAll)
+	if err != nil {
+		return regionScope{}, err
+	}
+	if all {
+		return regionScope{all: true}, nil
+	}
+
+	regions, err := o.adminService.GetAllRegionCode()
+	if err != nil {
+		return regionScope{}, err
+	}
+
+	scope := regionScope{codes: make(map[string]bool)}
+	for _, region := range regions {
+		if region.AdminID == uint(adminIdLogin) {
+			scope.codes[region.ID] = true
+		}
+	}
+	return scope, nil
+}
+
+// requireRegion menolak admin yang tidak bertanggung jawab atas kode wilayah.
+func (o *orderService) requireRegion(adminIdLogin int, code string) error {
+	scope, err := o.regionScopeOf(adminIdLogin)
+	if err != nil {
+		return err
+	}
+	if !scope.allows(code) {
+		return errors.New("kode wilayah bukan tanggung jawab anda")
+	}
+	return nil
+}
+
+// requireAllRegions dipakai untuk operasi yang mencakup semua wilayah, misalnya satu batch penuh.
+func (o *orderService) requireAllRegions(adminIdLogin int) error {
+	scope, err := o.regionScopeOf(adminIdLogin)
+	if err != nil {
+		return err
+	}
+	if !scope.all {
+		return errors.New("fitur ini hanya untuk admin yang mengelola semua wilayah")
+	}
+	return nil
+}
+
+// orderInScope mengambil order jika berada di wilayah admin. Order di luar wilayah dianggap tidak ada.
+func (o *orderService) orderInScope(adminIdLogin int, userOrderId uint) (*order.UserOrder, error) {
+	userOrder, err := o.orderData.SelectById(userOrderId)
+	if err != nil {
+		return nil, errors.New("order tidak ditemukan")
 	}
 
-	orderIdCheck, err := o.orderData.SelectById(userOrderId)
+	scope, err := o.regionScopeOf(adminIdLogin)
+	if err != nil {
+		return nil, err
+	}
+	if !scope.allows(userOrder.RegionCode) {
+		return nil, errors.New("order tidak ditemukan")
+	}
+	return userOrder, nil
+}
+
+// filterOrders membuang order di luar wilayah admin.
+func (o *orderService) filterOrders(adminIdLogin int, userOrders []order.UserOrder) ([]order.UserOrder, error) {
+	scope, err := o.regionScopeOf(adminIdLogin)
+	if err != nil {
+		return nil, err
+	}
+	if scope.all {
+		return userOrders, nil
+	}
+
+	var filtered []order.UserOrder
+	for _, userOrder := range userOrders {
+		if scope.allows(userOrder.RegionCode) {
+			filtered = append(filtered, userOrder)
+		}
+	}
+	return filtered, nil
+}
+
+// CreateOrderDetail implements order.OrderServiceInterface.
+func (o *orderService) CreateOrderDetail(adminIdLogin int, userOrderId uint, inputOrder order.OrderDetail) error {
+	orderIdCheck, err := o.orderInScope(adminIdLogin, userOrderId)
 	if err != nil {
 		return err
 	}
@@ -123,7 +252,7 @@ func (o *orderService) GetUserOrderProcess(userIdLogin int) ([]order.UserOrder,
 	if err != nil {
 		return nil, err
 	}
-	return userOrders, nil
+	return applyStorageFee(userOrders), nil
 }
 
 // SearchUserOrder implements order.OrderServiceInterface.
@@ -137,40 +266,37 @@ func (o *orderService) SearchUserOrder(userIdLogin int, itemName string) ([]orde
 
 // GetAllUserOrderWait implements order.OrderServiceInterface.
 func (o *orderService) GetAllUserOrderWait(adminIdLogin int) ([]order.UserOrder, error) {
-	adminCheck, err := o.adminService.GetById(adminIdLogin)
-	if err != nil || adminCheck == nil {
-		return nil, errors.New("anda bukan admin")
-	}
-
 	userOrders, err := o.orderData.SelectAllUserOrderWait()
 	if err != nil {
 		return nil, err
 	}
 
-	return userOrders, nil
+	return o.filterOrders(adminIdLogin, userOrders)
 }
 
 // GetDeliveryBatchWithRegion implements order.OrderServiceInterface.
 func (o *orderService) GetDeliveryBatchWithRegion(adminIdLogin int) ([]order.DeliveryBatchWithRegion, error) {
-	adminCheck, err := o.adminService.GetById(adminIdLogin)
-	if err != nil || adminCheck == nil {
-		return nil, errors.New("anda bukan admin")
+	deliveryBatchWithRegion, err := o.orderData.FetchDeliveryBatchWithRegion()
+	if err != nil {
+		return nil, err
 	}
 
-	deliveryBatchWithRegion, err := o.orderData.FetchDeliveryBatchWithRegion()
+	scope, err := o.regionScopeOf(adminIdLogin)
 	if err != nil {
 		return nil, err
 	}
-	return deliveryBatchWithRegion, nil
+
+	var filtered []order.DeliveryBatchWithRegion
+	for _, batch := range deliveryBatchWithRegion {
+		if scope.allows(batch.RegionCode) {
+			filtered = append(filtered, batch)
+		}
+	}
+	return filtered, nil
 }
 
 // GetNameByUserOrder implements order.OrderServiceInterface.
 func (o *orderService) GetNameByUserOrder(adminIdLogin int, code, batch string) ([]order.UserOrder, error) {
-	adminCheck, err := o.adminService.GetById(adminIdLogin)
-	if err != nil || adminCheck == nil {
-		return nil, errors.New("anda bukan admin")
-	}
-
 	codeCheck, err := o.adminService.GettByIdRegion(code)
 	if err != nil || codeCheck == nil {
 		return nil, errors.New("code region tidak ada")
@@ -181,6 +307,11 @@ func (o *orderService) GetNameByUserOrder(adminIdLogin int, code, batch string)
 		return nil, errors.New("delivery batch tidak ada")
 	}
 
+	err = o.requireRegion(adminIdLogin, code)
+	if err != nil {
+		return nil, err
+	}
+
 	userOrders, err := o.orderData.SelectNameByUserOrder(code, batch)
 	if err != nil {
 		return nil, err
@@ -190,11 +321,6 @@ func (o *orderService) GetNameByUserOrder(adminIdLogin int, code, batch string)
 
 // GetOrderByUserOrderNameUser implements order.OrderServiceInterface.
 func (o *orderService) GetOrderByUserOrderNameUser(adminIdLogin int, code string, batch string, name string) ([]order.UserOrder, error) {
-	adminCheck, err := o.adminService.GetById(adminIdLogin)
-	if err != nil || adminCheck == nil {
-		return nil, errors.New("anda bukan admin")
-	}
-
 	codeCheck, err := o.adminService.GettByIdRegion(code)
 	if err != nil || codeCheck == nil {
 		return nil, errors.New("code region tidak ada")
@@ -205,20 +331,20 @@ func (o *orderService) GetOrderByUserOrderNameUser(adminIdLogin int, code string
 		return nil, errors.New("delivery batch tidak ada")
 	}
 
+	err = o.requireRegion(adminIdLogin, code)
+	if err != nil {
+		return nil, err
+	}
+
 	userOrders, err := o.orderData.SelectOrderByUserOrderNameUser(code, batch, name)
 	if err != nil {
 		return nil, err
 	}
-	return userOrders, nil
+	return applyStorageFee(userOrders), nil
 }
 
 // UpdateEstimationForOrders implements order.OrderServiceInterface.
 func (o *orderService) UpdateEstimationForOrders(adminIdLogin int, code, batch string, estimation *time.Time) error {
-	adminCheck, err := o.adminService.GetById(adminIdLogin)
-	if err != nil || adminCheck == nil {
-		return errors.New("anda bukan admin")
-	}
-
 	codeCheck, err := o.adminService.GettByIdRegion(code)
 	if err != nil || codeCheck == nil {
 		return errors.New("code region tidak ada")
@@ -229,6 +355,11 @@ func (o *orderService) UpdateEstimationForOrders(adminIdLogin int, code, batch s
 		return errors.New("delivery batch tidak ada")
 	}
 
+	err = o.requireRegion(adminIdLogin, code)
+	if err != nil {
+		return err
+	}
+
 	err = o.orderData.UpdateEstimationForOrders(code, batch, estimation)
 	if err != nil {
 		return err
@@ -239,9 +370,13 @@ func (o *orderService) UpdateEstimationForOrders(adminIdLogin int, code, batch s
 
 // UpdateOrderStatus implements order.OrderServiceInterface.
 func (o *orderService) UpdateOrderStatus(adminIdLogin int, userOrderId uint, status string) error {
-	adminCheck, err := o.adminService.GetById(adminIdLogin)
-	if err != nil || adminCheck.Role != "Perwakilan" {
-		return errors.New("anda bukan admin perwakilan")
+	if status == "Sudah Diambil" {
+		return errors.New("status Sudah Diambil hanya bisa diset dengan mencatat pengambilan barang")
+	}
+
+	_, err := o.orderInScope(adminIdLogin, userOrderId)
+	if err != nil {
+		return err
 	}
 
 	err = o.orderData.UpdateOrderStatus(userOrderId, status)
@@ -252,34 +387,40 @@ func (o *orderService) UpdateOrderStatus(adminIdLogin int, userOrderId uint, sta
 }
 
 // UploadFotoPacked implements order.OrderServiceInterface.
-func (o *orderService) UploadFotoPacked(adminIdLogin int, inputOrder order.PhotoOrder, photoPacked *multipart.FileHeader) error {
-	adminCheck, err := o.adminService.GetById(adminIdLogin)
-	if err != nil || adminCheck.Role != "Jakarta" {
-		return errors.New("anda bukan admin Jakarta")
-	}
-
+func (o *orderService) UploadFotoPacked(adminIdLogin int, inputOrder order.PhotoOrder, photoPacked *multipart.FileHeader) (uint, error) {
 	codeCheck, err := o.adminService.GettByIdRegion(inputOrder.RegionCodeID)
 	if err != nil || codeCheck == nil {
-		return errors.New("code region tidak ada")
+		return 0, errors.New("code region tidak ada")
 	}
 
 	batchCheck, err := o.adminService.GetDeliveryBatch(inputOrder.DeliveryBatchID)
 	if err != nil || batchCheck == nil {
-		return errors.New("delivery batch tidak ada")
+		return 0, errors.New("delivery batch tidak ada")
+	}
+
+	if photoPacked == nil {
+		return 0, errors.New("tidak ada foto yang di upload")
 	}
 
-	err = o.orderData.UploadFotoPacked(inputOrder, photoPacked)
+	err = o.requireRegion(adminIdLogin, inputOrder.RegionCodeID)
 	if err != nil {
-		return err
+		return 0, err
 	}
-	return nil
+
+	// Admin Jakarta yang mengunggah menjadi penanggung jawab foto
+	inputOrder.AdminID = uint(adminIdLogin)
+	return o.orderData.UploadFotoPacked(inputOrder, photoPacked)
 }
 
 // UploadFotoReceived implements order.OrderServiceInterface.
 func (o *orderService) UploadFotoReceived(adminIdLogin int, idFoto uint, photoReceived *multipart.FileHeader) error {
-	adminCheck, err := o.adminService.GetById(adminIdLogin)
-	if err != nil || adminCheck.Role != "Perwakilan" {
-		return errors.New("anda bukan admin perwakilan")
+	if photoReceived == nil {
+		return errors.New("tidak ada foto yang di upload")
+	}
+
+	err := o.requirePhotoOrderRegion(adminIdLogin, idFoto)
+	if err != nil {
+		return err
 	}
 
 	err = o.orderData.UploadFotoReceived(idFoto, photoReceived)
@@ -290,7 +431,12 @@ func (o *orderService) UploadFotoReceived(adminIdLogin int, idFoto uint, photoRe
 }
 
 // GenerateCSVByBatch implements order.OrderServiceInterface.
-func (o *orderService) GenerateCSVByBatch(batch, filePath string) error {
+func (o *orderService) GenerateCSVByBatch(adminIdLogin int, batch, filePath string) error {
+	err := o.requireAllRegions(adminIdLogin)
+	if err != nil {
+		return err
+	}
+
 	batchCheck, err := o.adminService.GetDeliveryBatch(batch)
 	if err != nil || batchCheck == nil {
 		return errors.New("batch tidak ada")
@@ -304,22 +450,80 @@ func (o *orderService) GenerateCSVByBatch(batch, filePath string) error {
 	return nil
 }
 
-// GetFoto implements order.OrderServiceInterface.
-func (o *orderService) GetFoto(batch string, code string, userId int) (*order.PhotoOrder, error) {
+// GetFotoForUser implements order.OrderServiceInterface.
+func (o *orderService) GetFotoForUser(userIdLogin int, batch string, code string, userId int) (*order.PhotoOrder, error) {
+	if userIdLogin == 0 || userIdLogin != userId {
+		return nil, errors.New("anda tidak memiliki akses ke foto ini")
+	}
+
 	fotoOrders, err := o.orderData.GetFoto(batch, code, userId)
 	if err != nil {
 		return nil, err
 	}
-	return fotoOrders, nil
+	return o.signPhotoOrder(fotoOrders)
 }
 
-// SearchOrders implements order.OrderServiceInterface.
-func (o *orderService) SearchOrders(adminIdLogin int, searchQuery string) ([]order.UserOrder, error) {
+// GetFotoForAdmin implements order.OrderServiceInterface.
+func (o *orderService) GetFotoForAdmin(adminIdLogin int, batch string, code string, userId int) (*order.PhotoOrder, error) {
 	adminCheck, err := o.adminService.GetById(adminIdLogin)
-	if err != nil || adminCheck == nil {
+	if err != nil {
 		return nil, errors.New("anda bukan admin")
 	}
 
+	fotoOrders, err := o.orderData.GetFoto(batch, code, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	allowed, err := o.canReadPhoto(adminCheck, fotoOrders.AdminID, fotoOrders.Region.AdminID)
+	if err != nil {
+		return nil, err
+	}
+	if !allowed {
+		return nil, errors.New("anda tidak memiliki akses ke foto ini")
+	}
+	return o.signPhotoOrder(fotoOrders)
+}
+
+// canReadPhoto mengecek akses admin ke foto order. Admin dengan permission photo.read.all
+// boleh melihat semua foto, admin lain hanya foto yang ia upload atau foto order di wilayahnya.
+// Foto lama belum mencatat admin pengunggah, sehingga dianggap milik semua admin penerima barang.
+func (o *orderService) canReadPhoto(adminCheck *admin.Admin, uploaderId, regionAdminId uint) (bool, error) {
+	if uploaderId == adminCheck.ID || regionAdminId == adminCheck.ID {
+		return true, nil
+	}
+
+	readAll, err := o.adminService.HasPermission(int(adminCheck.ID), admin.PermissionPhotoReadAll)
+	if err != nil || readAll {
+		return readAll, err
+	}
+
+	if uploaderId == 0 {
+		return o.adminService.HasPermission(int(adminCheck.ID), admin.PermissionOrderReceive)
+	}
+	return false, nil
+}
+
+// signPhotoOrder mengganti key foto dengan signed URL yang berlaku sementara.
+func (o *orderService) signPhotoOrder(fotoOrders *order.PhotoOrder) (*order.PhotoOrder, error) {
+	var err error
+	if fotoOrders.PhotoPacked != "" {
+		fotoOrders.PhotoPacked, err = o.uploader.SignedURL(fotoOrders.PhotoPacked)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if fotoOrders.PhotoReceived != "" {
+		fotoOrders.PhotoReceived, err = o.uploader.SignedURL(fotoOrders.PhotoReceived)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return fotoOrders, nil
+}
+
+// SearchOrders implements order.OrderServiceInterface.
+func (o *orderService) SearchOrders(adminIdLogin int, searchQuery string) ([]order.UserOrder, error) {
 	if searchQuery == "" {
 		return nil, errors.New("anda belum mengetikan sesuatu")
 	}
@@ -329,19 +533,22 @@ func (o *orderService) SearchOrders(adminIdLogin int, searchQuery string) ([]ord
 		return nil, err
 	}
 
-	return orderResponse, nil
+	return o.filterOrders(adminIdLogin, orderResponse)
 }
 
 // UpdateOrderByID implements order.OrderServiceInterface.
 func (o *orderService) UpdateOrderByID(adminIdLogin int, orderID uint, inputOrder order.UpdateOrderByID) error {
-	adminCheck, err := o.adminService.GetById(adminIdLogin)
-	if err != nil || adminCheck.Role != "Super" {
-		return errors.New("anda bukan admin super")
+	_, err := o.orderInScope(adminIdLogin, orderID)
+	if err != nil {
+		return err
 	}
 
-	_, err = o.orderData.SelectById(orderID)
-	if err != nil {
-		return errors.New("order tidak ada")
+	// order tidak boleh dipindahkan ke wilayah di luar tanggung jawab admin
+	if inputOrder.RegionCode != "" {
+		err = o.requireRegion(adminIdLogin, inputOrder.RegionCode)
+		if err != nil {
+			return err
+		}
 	}
 
 	err = o.orderData.UpdateOrderByID(orderID, inputOrder)
@@ -354,15 +561,679 @@ func (o *orderService) UpdateOrderByID(adminIdLogin int, orderID uint, inputOrde
 
 // FetchRegionStatsByBatch implements order.OrderServiceInterface.
 func (o *orderService) FetchRegionStatsByBatch(adminIdLogin int, batch string) ([]order.RegionBatchStats, error) {
+	statsResponse, err := o.orderData.FetchRegionStatsByBatch(batch)
+	if err != nil {
+		return nil, err
+	}
+
+	scope, err := o.regionScopeOf(adminIdLogin)
+	if err != nil {
+		return nil, err
+	}
+
+	var filtered []order.RegionBatchStats
+	for _, stats := range statsResponse {
+		if scope.allows(stats.RegionCode) {
+			filtered = append(filtered, stats)
+		}
+	}
+	return filtered, nil
+}
+
+// ShipDeliveryBatch implements order.OrderServiceInterface.
+func (o *orderService) ShipDeliveryBatch(adminIdLogin int, batch string) ([]order.BatchEstimation, error) {
+	err := o.requireAllRegions(adminIdLogin)
+	if err != nil {
+		return nil, err
+	}
+
+	batchCheck, err := o.adminService.GetDeliveryBatch(batch)
+	if err != nil || batchCheck == nil {
+		return nil, errors.New("delivery batch tidak ada")
+	}
+
+	if batchCheck.ShippedAt != nil {
+		return nil, errors.New("batch sudah ditandai dikirim")
+	}
+
+	holidays, err := o.adminService.GetAllHoliday()
+	if err != nil {
+		return nil, err
+	}
+
+	var holidayDates []time.Time
+	for _, holiday := range holidays {
+		holidayDates = append(holidayDates, holiday.Date)
+	}
+
+	codes, err := o.orderData.SelectRegionCodesByBatch(batch)
+	if err != nil {
+		return nil, err
+	}
+
+	// Estimasi dihitung dari tanggal pengiriman, bukan jam pengiriman
+	shippedAt := time.Now()
+	shippedDate := time.Date(shippedAt.Year(), shippedAt.Month(), shippedAt.Day(), 0, 0, 0, 0, shippedAt.Location())
+
+	var estimations []order.BatchEstimation
+	for _, code := range codes {
+		region, err := o.adminService.GettByIdRegion(code)
+		if err != nil {
+			return nil, err
+		}
+
+		// Lewati kode wilayah yang belum memiliki pengaturan lama pengiriman
+		if region.MaxLeadDays == 0 {
+			continue
+		}
+
+		minDays := region.MinLeadDays
+		if minDays == 0 {
+			minDays = region.MaxLeadDays
+		}
+
+		estimations = append(estimations, order.BatchEstimation{
+			RegionCode:        region.ID,
+			Region:            region.Region,
+			EarliestDelivery:  timejkt.AddWorkingDays(shippedDate, minDays, holidayDates),
+			EstimatedDelivery: timejkt.AddWorkingDays(shippedDate, region.MaxLeadDays, holidayDates),
+		})
+	}
+
+	// Biaya penyimpanan dikunci saat batch dikirim
+	batchOrders, err := o.orderData.FetchOrdersByBatch(batch)
+	if err != nil {
+		return nil, err
+	}
+	storageFees := make(map[uint]int)
+	for _, userOrder := range batchOrders {
+		if userOrder.OrderDetails.ReceivedAt == nil {
+			continue
+		}
+		_, _, fee := calculateStorageFee(*userOrder.OrderDetails.ReceivedAt, shippedAt)
+		storageFees[userOrder.ID] = fee
+	}
+
+	err = o.orderData.ShipBatch(batch, shippedAt, storageFees, estimations)
+	if err != nil {
+		return nil, err
+	}
+
+	return estimations, nil
+}
+
+// GenerateManifestByBatch implements order.OrderServiceInterface.
+func (o *orderService) GenerateManifestByBatch(adminIdLogin int, batch, code, format, filePath string) error {
 	adminCheck, err := o.adminService.GetById(adminIdLogin)
-	if err != nil || adminCheck.Role != "Super" {
-		return nil, errors.New("anda bukan admin super")
+	if err != nil || adminCheck == nil {
+		return errors.New("anda bukan admin")
 	}
 
-	statsResponse, err := o.orderData.FetchRegionStatsByBatch(batch)
+	if format != "pdf" && format != "html" {
+		return errors.New("format manifest harus pdf atau html")
+	}
+
+	err = o.requireRegion(adminIdLogin, code)
+	if err != nil {
+		return err
+	}
+
+	region, err := o.adminService.GettByIdRegion(code)
+	if err != nil || region == nil {
+		return errors.New("code region tidak ada")
+	}
+
+	batchCheck, err := o.adminService.GetDeliveryBatch(batch)
+	if err != nil || batchCheck == nil {
+		return errors.New("delivery batch tidak ada")
+	}
+
+	orders, err := o.orderData.FetchOrdersByBatch(batch)
+	if err != nil {
+		return err
+	}
+
+	perwakilanName := ""
+	perwakilan, err := o.adminService.GetById(int(region.AdminID))
+	if err == nil && perwakilan != nil {
+		perwakilanName = perwakilan.Name
+	}
+
+	data := manifest.Manifest{
+		BatchPengiriman:     batch,
+		KodeWilayah:         region.ID,
+		Wilayah:             region.Region,
+		AlamatWilayah:       region.FullAddress,
+		HargaPerKodeWilayah: region.Price,
+		TanggalCetak:        timejkt.FormatDateToIndonesian(time.Now()),
+		AdminJakarta:        adminCheck.Name,
+		AdminPerwakilan:     perwakilanName,
+	}
+
+	for _, userOrder := range orders {
+		if userOrder.Region.ID != code {
+			continue
+		}
+
+		price := order.ItemPrice(userOrder.OrderDetails.WeightItem, region.Price)
+		data.Orders = append(data.Orders, manifest.ManifestOrder{
+			NomorOrder:           fmt.Sprintf("%d", userOrder.ID),
+			NamaPenerima:         userOrder.User.Name,
+			NomorTeleponWhatsapp: fmt.Sprintf("%d", userOrder.WhatsAppNumber),
+			NamaBarang:           userOrder.ItemName,
+			NomorResi:            userOrder.TrackingNumber,
+			NomorResiJastip:      userOrder.OrderDetails.TrackingNumberJastip,
+			Berat:                userOrder.OrderDetails.WeightItem,
+			Harga:                price,
+		})
+		data.TotalOrder++
+		data.TotalBerat += userOrder.OrderDetails.WeightItem
+		data.TotalHarga += price
+	}
+
+	if data.TotalOrder == 0 {
+		return errors.New("tidak ada order untuk kode wilayah pada batch ini")
+	}
+
+	if format == "html" {
+		return o.manifest.GenerateHTML(filePath, data)
+	}
+	return o.manifest.GeneratePDF(filePath, data)
+}
+
+// boxLabelPrefix menandai kode label untuk kotak pengiriman per batch dan kode wilayah
+const boxLabelPrefix = "BOX-"
+
+func orderToLabel(userOrder order.UserOrder) label.Label {
+	batch := ""
+	if userOrder.OrderDetails.DeliveryBatchID != nil {
+		batch = *userOrder.OrderDetails.DeliveryBatchID
+	}
+
+	return label.Label{
+		Code:       userOrder.OrderDetails.TrackingNumberJastip,
+		Title:      "JASTIP JAKARTA",
+		Batch:      batch,
+		RegionCode: userOrder.Region.ID,
+		Recipient:  userOrder.User.Name,
+		Weight:     userOrder.OrderDetails.WeightItem,
+	}
+}
+
+// boxLabel membuat label kotak beserta daftar order di dalamnya.
+func (o *orderService) boxLabel(batch, code string) (label.Label, []order.UserOrder, error) {
+	region, err := o.adminService.GettByIdRegion(code)
+	if err != nil || region == nil {
+		return label.Label{}, nil, errors.New("code region tidak ada")
+	}
+
+	batchCheck, err := o.adminService.GetDeliveryBatch(batch)
+	if err != nil || batchCheck == nil {
+		return label.Label{}, nil, errors.New("delivery batch tidak ada")
+	}
+
+	orders, err := o.orderData.SelectNameByUserOrder(code, batch)
+	if err != nil {
+		return label.Label{}, nil, err
+	}
+	if len(orders) == 0 {
+		return label.Label{}, nil, errors.New("tidak ada order untuk kode wilayah pada batch ini")
+	}
+
+	totalWeight := 0.0
+	for _, userOrder := range orders {
+		totalWeight += userOrder.OrderDetails.WeightItem
+	}
+
+	box := label.Label{
+		Code:       boxLabelPrefix + batch + "-" + code,
+		Title:      fmt.Sprintf("KOTAK JASTIP (%d order)", len(orders)),
+		Batch:      batch,
+		RegionCode: region.ID,
+		Recipient:  "Perwakilan " + region.Region,
+		Weight:     totalWeight,
+	}
+	return box, orders, nil
+}
+
+// GenerateOrderLabel implements order.OrderServiceInterface.
+func (o *orderService) GenerateOrderLabel(adminIdLogin int, orderID uint, format, filePath string) error {
+	userOrder, err := o.orderInScope(adminIdLogin, orderID)
+	if err != nil {
+		return err
+	}
+
+	if userOrder.OrderDetails.TrackingNumberJastip == "" {
+		return errors.New("order belum memiliki nomor resi jastip")
+	}
+
+	switch format {
+	case "png":
+		return o.label.GeneratePNG(filePath, orderToLabel(*userOrder))
+	case "pdf":
+		return o.label.GeneratePDF(filePath, "a6", []label.Label{orderToLabel(*userOrder)})
+	default:
+		return errors.New("format label harus png atau pdf")
+	}
+}
+
+// GenerateBoxLabel implements order.OrderServiceInterface.
+func (o *orderService) GenerateBoxLabel(adminIdLogin int, batch, code, format, filePath string) error {
+	err := o.requireRegion(adminIdLogin, code)
+	if err != nil {
+		return err
+	}
+
+	box, _, err := o.boxLabel(batch, code)
+	if err != nil {
+		return err
+	}
+
+	switch format {
+	case "png":
+		return o.label.GeneratePNG(filePath, box)
+	case "pdf":
+		return o.label.GeneratePDF(filePath, "a6", []label.Label{box})
+	default:
+		return errors.New("format label harus png atau pdf")
+	}
+}
+
+// GenerateBatchLabels implements order.OrderServiceInterface.
+func (o *orderService) GenerateBatchLabels(adminIdLogin int, batch, code, size, filePath string) error {
+	if size != "a4" && size != "a6" {
+		return errors.New("ukuran label harus a4 atau a6")
+	}
+
+	err := o.requireRegion(adminIdLogin, code)
+	if err != nil {
+		return err
+	}
+
+	box, orders, err := o.boxLabel(batch, code)
+	if err != nil {
+		return err
+	}
+
+	labels := []label.Label{box}
+	for _, userOrder := range orders {
+		if userOrder.OrderDetails.TrackingNumberJastip == "" {
+			continue
+		}
+		labels = append(labels, orderToLabel(userOrder))
+	}
+
+	return o.label.GeneratePDF(filePath, size, labels)
+}
+
+// ScanLabel implements order.OrderServiceInterface.
+func (o *orderService) ScanLabel(adminIdLogin int, code string) ([]order.UserOrder, error) {
+	code = strings.TrimSpace(code)
+	if code == "" {
+		return nil, errors.New("kode label tidak boleh kosong")
+	}
+
+	// Label kotak berisi semua order pada batch dan kode wilayah tersebut
+	if strings.HasPrefix(code, boxLabelPrefix) {
+		parts := strings.SplitN(strings.TrimPrefix(code, boxLabelPrefix), "-", 2)
+		if len(parts) != 2 {
+			return nil, errors.New("kode label kotak tidak valid")
+		}
+		err := o.requireRegion(adminIdLogin, parts[1])
+		if err != nil {
+			return nil, err
+		}
+		return o.orderData.SelectNameByUserOrder(parts[1], parts[0])
+	}
+
+	userOrder, err := o.orderData.SelectByTrackingNumberJastip(code)
+	if err != nil {
+		return nil, errors.New("order dengan kode label tersebut tidak ditemukan")
+	}
+
+	userOrders, err := o.filterOrders(adminIdLogin, []order.UserOrder{*userOrder})
 	if err != nil {
 		return nil, err
 	}
+	if len(userOrders) == 0 {
+		return nil, errors.New("order dengan kode label tersebut tidak ditemukan")
+	}
+	return userOrders, nil
+}
+
+// MoveShelfLocation implements order.OrderServiceInterface.
+func (o *orderService) MoveShelfLocation(adminIdLogin int, userOrderId uint, location string) error {
+	location = strings.ToUpper(strings.TrimSpace(location))
+	if location == "" {
+		return errors.New("lokasi rak tidak boleh kosong")
+	}
 
-	return statsResponse, nil
+	userOrder, err := o.orderInScope(adminIdLogin, userOrderId)
+	if err != nil {
+		return err
+	}
+	if userOrder.OrderDetails.Status == "Menunggu Diterima" {
+		return errors.New("order belum diterima di gudang")
+	}
+
+	return o.orderData.UpdateShelfLocation(adminIdLogin, userOrderId, location)
+}
+
+// GetShelfLocationHistory implements order.OrderServiceInterface.
+func (o *orderService) GetShelfLocationHistory(adminIdLogin int, userOrderId uint) ([]order.ShelfLocationHistory, error) {
+	_, err := o.orderInScope(adminIdLogin, userOrderId)
+	if err != nil {
+		return nil, err
+	}
+	return o.orderData.SelectShelfLocationHistory(userOrderId)
+}
+
+// FindOrderLocation implements order.OrderServiceInterface.
+func (o *orderService) FindOrderLocation(adminIdLogin int, trackingNumber string) (*order.UserOrder, error) {
+	trackingNumber = strings.TrimSpace(trackingNumber)
+	if trackingNumber == "" {
+		return nil, errors.New("nomor resi tidak boleh kosong")
+	}
+
+	userOrder, err := o.orderData.SelectByTrackingNumber(trackingNumber)
+	if err != nil {
+		return nil, errors.New("order dengan nomor resi tersebut tidak ditemukan")
+	}
+
+	scope, err := o.regionScopeOf(adminIdLogin)
+	if err != nil {
+		return nil, err
+	}
+	if !scope.allows(userOrder.RegionCode) {
+		return nil, errors.New("order dengan nomor resi tersebut tidak ditemukan")
+	}
+	return userOrder, nil
+}
+
+// GetPickList implements order.OrderServiceInterface.
+func (o *orderService) GetPickList(adminIdLogin int, batch, code string) ([]order.UserOrder, error) {
+	batchCheck, err := o.adminService.GetDeliveryBatch(batch)
+	if err != nil || batchCheck == nil {
+		return nil, errors.New("delivery batch tidak ada")
+	}
+
+	userOrders, err := o.orderData.SelectPickList(batch, code)
+	if err != nil {
+		return nil, err
+	}
+	return o.filterOrders(adminIdLogin, userOrders)
+}
+
+// calculateStorageFee menghitung lama penyimpanan dalam hari, hari yang dikenakan biaya
+// setelah masa gratis, dan total biaya penyimpanan.
+func calculateStorageFee(receivedAt, until time.Time) (days, chargeableDays, fee int) {
+	days = int(until.Sub(receivedAt).Hours() / 24)
+	if days < 0 {
+		days = 0
+	}
+
+	chargeableDays = days - config.STORAGE_FREE_DAYS
+	if chargeableDays < 0 {
+		chargeableDays = 0
+	}
+
+	return days, chargeableDays, chargeableDays * config.STORAGE_FEE_PER_DAY
+}
+
+// applyStorageFee mengisi biaya penyimpanan berjalan untuk order yang belum dikirim.
+// Order yang batch-nya sudah dikirim memakai biaya yang sudah dikunci.
+func applyStorageFee(userOrders []order.UserOrder) []order.UserOrder {
+	now := time.Now()
+	for i, userOrder := range userOrders {
+		if userOrder.OrderDetails.ReceivedAt == nil || userOrder.OrderDetails.StorageEndedAt != nil {
+			continue
+		}
+		_, _, fee := calculateStorageFee(*userOrder.OrderDetails.ReceivedAt, now)
+		userOrders[i].OrderDetails.StorageFee = fee
+	}
+	return userOrders
+}
+
+// GetStorageAging implements order.OrderServiceInterface.
+func (o *orderService) GetStorageAging(adminIdLogin int, minDays int) ([]order.StorageAging, error) {
+	userOrders, err := o.orderData.SelectStoredOrders()
+	if err != nil {
+		return nil, err
+	}
+
+	userOrders, err = o.filterOrders(adminIdLogin, userOrders)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	var agings []order.StorageAging
+	for _, userOrder := range userOrders {
+		days, chargeableDays, fee := calculateStorageFee(*userOrder.OrderDetails.ReceivedAt, now)
+		if days < minDays {
+			continue
+		}
+		agings = append(agings, order.StorageAging{
+			UserOrder:      userOrder,
+			DaysStored:     days,
+			ChargeableDays: chargeableDays,
+			StorageFee:     fee,
+		})
+	}
+	return agings, nil
+}
+
+// kategori foto kondisi barang saat diterima
+var conditionPhotoCategories = map[string]bool{
+	"kotak_luar": true,
+	"label":      true,
+	"isi":        true,
+	"kerusakan":  true,
+}
+
+// UploadConditionPhotos implements order.OrderServiceInterface.
+func (o *orderService) UploadConditionPhotos(adminIdLogin int, userOrderId uint, category string, photos []*multipart.FileHeader) error {
+	if !conditionPhotoCategories[category] {
+		return errors.New("kategori foto tidak valid, gunakan kotak_luar, label, isi atau kerusakan")
+	}
+
+	if len(photos) == 0 {
+		return errors.New("tidak ada foto yang di upload")
+	}
+
+	_, err := o.orderInScope(adminIdLogin, userOrderId)
+	if err != nil {
+		return err
+	}
+
+	for _, photo := range photos {
+		err = o.orderData.InsertConditionPhoto(order.ConditionPhoto{
+			UserOrderID: userOrderId,
+			AdminID:     uint(adminIdLogin),
+			Category:    category,
+		}, photo)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DeleteConditionPhoto implements order.OrderServiceInterface.
+func (o *orderService) DeleteConditionPhoto(adminIdLogin int, userOrderId uint, photoId uint) error {
+	_, err := o.orderInScope(adminIdLogin, userOrderId)
+	if err != nil {
+		return err
+	}
+	return o.orderData.DeleteConditionPhoto(userOrderId, photoId)
+}
+
+// ReplaceFotoPacked implements order.OrderServiceInterface.
+func (o *orderService) ReplaceFotoPacked(adminIdLogin int, idFoto uint, photoPacked *multipart.FileHeader) error {
+	if photoPacked == nil {
+		return errors.New("tidak ada foto yang di upload")
+	}
+
+	err := o.requirePhotoOrderRegion(adminIdLogin, idFoto)
+	if err != nil {
+		return err
+	}
+	return o.orderData.ReplaceFotoPacked(idFoto, photoPacked)
+}
+
+// DeletePhotoOrder implements order.OrderServiceInterface.
+func (o *orderService) DeletePhotoOrder(adminIdLogin int, idFoto uint) error {
+	err := o.requirePhotoOrderRegion(adminIdLogin, idFoto)
+	if err != nil {
+		return err
+	}
+	return o.orderData.DeletePhotoOrder(idFoto)
+}
+
+// requirePhotoOrderRegion menolak perubahan foto batch milik wilayah di luar tanggung jawab admin.
+func (o *orderService) requirePhotoOrderRegion(adminIdLogin int, idFoto uint) error {
+	photoOrder, err := o.orderData.SelectPhotoOrderById(idFoto)
+	if err != nil {
+		return errors.New("foto tidak ditemukan")
+	}
+	return o.requireRegion(adminIdLogin, photoOrder.RegionCodeID)
+}
+
+// GetConditionPhotosForUser implements order.OrderServiceInterface.
+func (o *orderService) GetConditionPhotosForUser(userIdLogin int, userOrderId uint) ([]order.ConditionPhoto, error) {
+	userOrder, err := o.orderData.SelectById(userOrderId)
+	if err != nil {
+		return nil, errors.New("order tidak ditemukan")
+	}
+
+	if userIdLogin == 0 || uint(userIdLogin) != userOrder.UserID {
+		return nil, errors.New("anda tidak memiliki akses ke foto ini")
+	}
+	return o.signConditionPhotos(userOrder.ConditionPhotos)
+}
+
+// GetConditionPhotosForAdmin implements order.OrderServiceInterface.
+func (o *orderService) GetConditionPhotosForAdmin(adminIdLogin int, userOrderId uint) ([]order.ConditionPhoto, error) {
+	adminCheck, err := o.adminService.GetById(adminIdLogin)
+	if err != nil {
+		return nil, errors.New("anda bukan admin")
+	}
+
+	userOrder, err := o.orderData.SelectById(userOrderId)
+	if err != nil {
+		return nil, errors.New("order tidak ditemukan")
+	}
+
+	var photos []order.ConditionPhoto
+	for _, photo := range userOrder.ConditionPhotos {
+		allowed, err := o.canReadPhoto(adminCheck, photo.AdminID, userOrder.Region.AdminID)
+		if err != nil {
+			return nil, err
+		}
+		if allowed {
+			photos = append(photos, photo)
+		}
+	}
+
+	if len(userOrder.ConditionPhotos) > 0 && len(photos) == 0 {
+		return nil, errors.New("anda tidak memiliki akses ke foto ini")
+	}
+	return o.signConditionPhotos(photos)
+}
+
+// signConditionPhotos mengganti key foto kondisi dan thumbnail-nya dengan signed URL.
+func (o *orderService) signConditionPhotos(photos []order.ConditionPhoto) ([]order.ConditionPhoto, error) {
+	for i, photo := range photos {
+		photoURL, err := o.uploader.SignedURL(photo.Photo)
+		if err != nil {
+			return nil, err
+		}
+
+		thumbnailURL, err := o.uploader.SignedThumbnailURL(photo.Photo)
+		if err != nil {
+			return nil, err
+		}
+
+		photos[i].Photo = photoURL
+		photos[i].Thumbnail = thumbnailURL
+	}
+	return photos, nil
+}
+
+// RecordPickup implements order.OrderServiceInterface.
+func (o *orderService) RecordPickup(adminIdLogin int, userOrderId uint, recipientName string, photo *multipart.FileHeader) error {
+	adminCheck, err := o.adminService.GetById(adminIdLogin)
+	if err != nil || adminCheck == nil {
+		return errors.New("anda bukan admin")
+	}
+
+	if strings.TrimSpace(recipientName) == "" {
+		return errors.New("nama penerima tidak boleh kosong")
+	}
+	if photo == nil {
+		return errors.New("foto atau tanda tangan penerima tidak boleh kosong")
+	}
+
+	userOrder, err := o.orderData.SelectById(userOrderId)
+	if err != nil {
+		return errors.New("order tidak ditemukan")
+	}
+
+	if userOrder.Region.AdminID != adminCheck.ID {
+		return errors.New("order bukan milik wilayah anda")
+	}
+	if userOrder.OrderDetails.Status == "Menunggu Diterima" {
+		return errors.New("order belum diterima di gudang Jakarta")
+	}
+	if userOrder.Pickup != nil {
+		return errors.New("order sudah diambil")
+	}
+	if !o.batchShipped(userOrder) {
+		return errors.New("batch order ini belum dikirim dari gudang Jakarta")
+	}
+
+	return o.orderData.InsertPickup(order.OrderPickup{
+		UserOrderID:   userOrderId,
+		AdminID:       adminCheck.ID,
+		RecipientName: strings.TrimSpace(recipientName),
+		PickedUpAt:    time.Now(),
+	}, photo)
+}
+
+// batchShipped memastikan barang sudah berangkat dari gudang Jakarta. Barang dari batch yang
+// dikirim sebelum ada shipped_at dikenali dari masa simpannya yang sudah ditutup.
+func (o *orderService) batchShipped(userOrder *order.UserOrder) bool {
+	if userOrder.OrderDetails.StorageEndedAt != nil {
+		return true
+	}
+	if userOrder.OrderDetails.DeliveryBatchID == nil {
+		return false
+	}
+	batch, err := o.adminService.GetDeliveryBatch(*userOrder.OrderDetails.DeliveryBatchID)
+	if err != nil || batch == nil {
+		return false
+	}
+	return batch.ShippedAt != nil
+}
+
+// GetPickupForUser implements order.OrderServiceInterface.
+func (o *orderService) GetPickupForUser(userIdLogin int, userOrderId uint) (*order.OrderPickup, error) {
+	userOrder, err := o.orderData.SelectById(userOrderId)
+	if err != nil {
+		return nil, errors.New("order tidak ditemukan")
+	}
+
+	if userIdLogin == 0 || uint(userIdLogin) != userOrder.UserID {
+		return nil, errors.New("anda tidak memiliki akses ke data ini")
+	}
+	if userOrder.Pickup == nil {
+		return nil, nil
+	}
+
+	pickup := *userOrder.Pickup
+	pickup.Photo, err = o.uploader.SignedURL(pickup.Photo)
+	if err != nil {
+		return nil, err
+	}
+	return &pickup, nil
 }
@@ -2,6 +2,7 @@ package data
 
 import (
 	"jastip-jakarta/features/user"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -9,11 +10,13 @@ import (
 type User struct {
 	ID uint `gorm:"primaryKey" json:"id"`
 	gorm.Model
-	Name         string
-	Email        string
-	Password     string
-	PhoneNumber  int
-	PhotoProfile string
+	Name            string
+	Email           string
+	Password        string
+	PhoneNumber     int
+	PhotoProfile    string
+	EmailVerifiedAt *time.Time
+	PhoneVerifiedAt *time.Time
 }
 
 func UserToModel(input user.User) User {
@@ -24,18 +27,23 @@ func UserToModel(input user.User) User {
 		Password:     input.Password,
 		PhoneNumber:  input.PhoneNumber,
 		PhotoProfile: input.PhotoProfile,
+
+		EmailVerifiedAt: input.EmailVerifiedAt,
+		PhoneVerifiedAt: input.PhoneVerifiedAt,
 	}
 }
 
 func (u User) ModelToUser() user.User {
 	return user.User{
-		ID:           u.ID,
-		Name:         u.Name,
-		Email:        u.Email,
-		Password:     u.Password,
-		PhoneNumber:  u.PhoneNumber,
-		PhotoProfile: u.PhotoProfile,
-		CreatedAt:    u.CreatedAt,
-		UpdatedAt:    u.UpdatedAt,
+		ID:              u.ID,
+		Name:            u.Name,
+		Email:           u.Email,
+		Password:        u.Password,
+		PhoneNumber:     u.PhoneNumber,
+		PhotoProfile:    u.PhotoProfile,
+		EmailVerifiedAt: u.EmailVerifiedAt,
+		PhoneVerifiedAt: u.PhoneVerifiedAt,
+		CreatedAt:       u.CreatedAt,
+		UpdatedAt:       u.UpdatedAt,
 	}
 }
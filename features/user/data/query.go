@@ -3,56 +3,58 @@ package data
 import (
 	"errors"
 	"jastip-jakarta/features/user"
-	"jastip-jakarta/utils/cloudinary"
+	"jastip-jakarta/utils/storage"
+	"log"
 	"mime/multipart"
 	"strconv"
 	"strings"
+	"time"
 
 	"gorm.io/gorm"
 )
 
 type userQuery struct {
-	db  *gorm.DB
-	cld cloudinary.CloudinaryUploaderInterface
+	db       *gorm.DB
+	uploader storage.UploaderInterface
 }
 
-func New(db *gorm.DB, cloudinaryUploader cloudinary.CloudinaryUploaderInterface) user.UserDataInterface {
+func New(db *gorm.DB, uploader storage.UploaderInterface) user.UserDataInterface {
 	return &userQuery{
-		db:  db,
-		cld: cloudinaryUploader,
+		db:       db,
+		uploader: uploader,
 	}
 }
 
 // Insert implements user.UserDataInterface.
-func (u *userQuery) Insert(input user.User) error {
+func (u *userQuery) Insert(input user.User) (uint, error) {
 	// Cek apakah email sudah ada
 	var emailCheck User
 	emailResult := u.db.Where("email = ?", input.Email).First(&emailCheck)
 	if emailResult.RowsAffected > 0 {
-		return errors.New("email sudah terdaftar")
+		return 0, errors.New("email sudah terdaftar")
 	}
 
 	// Cek apakah nama sudah ada
 	var nameCheck User
 	nameResult := u.db.Where("name = ?", input.Name).First(&nameCheck)
 	if nameResult.RowsAffected > 0 {
-		return errors.New("nama sudah terdaftar")
+		return 0, errors.New("nama sudah terdaftar")
 	}
 
 	// Cek apakah nomor telepon sudah ada
 	var phoneCheck User
 	phoneResult := u.db.Where("phone_number = ?", input.PhoneNumber).First(&phoneCheck)
 	if phoneResult.RowsAffected > 0 {
-		return errors.New("nomor telepon sudah terdaftar")
+		return 0, errors.New("nomor telepon sudah terdaftar")
 	}
 
 	// Jika tidak ada yang sama, lanjutkan dengan pembuatan akun baru
 	dataGorm := UserToModel(input)
 	tx := u.db.Create(&dataGorm)
 	if tx.Error != nil {
-		return tx.Error
+		return 0, tx.Error
 	}
-	return nil
+	return dataGorm.ID, nil
 }
 
 // Login implements user.UserDataInterface.
@@ -124,9 +126,14 @@ func (u *userQuery) Update(userIdLogin int, input user.User, photo *multipart.Fi
 
 	dataGorm := UserToModel(input)
 
+	var current User
+	if err := u.db.First(&current, userIdLogin).Error; err != nil {
+		return err
+	}
+
 	// Cek apakah ada file foto yang diupload
 	if photo != nil {
-		imageURL, err := u.cld.UploadImage(photo)
+		imageURL, err := u.uploader.UploadImage(photo)
 		if err != nil {
 			return err
 		}
@@ -135,8 +142,49 @@ func (u *userQuery) Update(userIdLogin int, input user.User, photo *multipart.Fi
 
 	tx := u.db.Model(&User{}).Where("id = ?", userIdLogin).Updates(dataGorm)
 	if tx.Error != nil {
+		u.uploader.DeleteImage(dataGorm.PhotoProfile)
 		return tx.Error
 	}
+
+	if err := u.resetChangedVerification(current, dataGorm); err != nil {
+		return err
+	}
+
+	// Foto lama dihapus dari storage setelah foto baru tersimpan
+	if photo != nil {
+		if err := u.uploader.DeleteImage(current.PhotoProfile); err != nil {
+			log.Printf("error deleting old user photo %s: %v", current.PhotoProfile, err)
+		}
+	}
+	return nil
+}
+
+// DeletePhoto implements user.UserDataInterface.
+func (u *userQuery) DeletePhoto(userIdLogin int) error {
+	var current User
+	if err := u.db.First(&current, userIdLogin).Error; err != nil {
+		return err
+	}
+	if current.PhotoProfile == "" {
+		return errors.New("tidak ada foto profil")
+	}
+
+	tx := u.db.Model(&User{}).Where("id = ?", userIdLogin).Update("photo_profile", "")
+	if tx.Error != nil {
+		return tx.Error
+	}
+	return u.uploader.DeleteImage(current.PhotoProfile)
+}
+
+// UpdatePassword implements user.UserDataInterface.
+func (u *userQuery) UpdatePassword(userId uint, hashedPassword string) error {
+	tx := u.db.Model(&User{}).Where("id = ?", userId).Update("password", hashedPassword)
+	if tx.Error != nil {
+		return tx.Error
+	}
+	if tx.RowsAffected == 0 {
+		return errors.New("user tidak ditemukan")
+	}
 	return nil
 }
 
@@ -191,7 +239,7 @@ func (u *userQuery) UpdateUserByName(name string, input user.User) error {
 		return tx.Error
 	}
 
-	return nil
+	return u.resetChangedVerification(existingUser, dataGorm)
 }
 
 // SelectAllUser implements user.UserDataInterface.
@@ -212,3 +260,33 @@ func (u *userQuery) SelectAllUser() ([]user.User, error) {
 
 	return usersData, nil
 }
+
+// resetChangedVerification membatalkan status verifikasi email atau nomor telepon yang diganti.
+func (u *userQuery) resetChangedVerification(current User, updated User) error {
+	reset := map[string]interface{}{}
+	if updated.Email != "" && updated.Email != current.Email {
+		reset["email_verified_at"] = nil
+	}
+	if updated.PhoneNumber != 0 && updated.PhoneNumber != current.PhoneNumber {
+		reset["phone_verified_at"] = nil
+	}
+	if len(reset) == 0 {
+		return nil
+	}
+	return u.db.Model(&User{}).Where("id = ?", current.ID).Updates(reset).Error
+}
+
+// UpdateVerification implements user.UserDataInterface.
+func (u *userQuery) UpdateVerification(userId uint, channel string, verifiedAt *time.Time) error {
+	column := ""
+	switch channel {
+	case user.VerificationEmail:
+		column = "email_verified_at"
+	case user.VerificationPhone:
+		column = "phone_verified_at"
+	default:
+		return errors.New("jenis verifikasi tidak valid")
+	}
+
+	return u.db.Model(&User{}).Where("id = ?", userId).Update(column, verifiedAt).Error
+}
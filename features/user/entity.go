@@ -1,30 +1,43 @@
 package user
 
 import (
+	"jastip-jakarta/features/auth"
 	"mime/multipart"
 	"time"
 )
 
 type User struct {
-	ID       uint
-	Name         string
-	Email        string
-	Password     string
-	PhoneNumber  int
-	PhotoProfile string
-	CreatedAt    time.Time
-	UpdatedAt    time.Time
+	ID              uint
+	Name            string
+	Email           string
+	Password        string
+	PhoneNumber     int
+	PhotoProfile    string
+	EmailVerifiedAt *time.Time
+	PhoneVerifiedAt *time.Time
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
 }
 
+// saluran verifikasi akun
+const (
+	VerificationEmail = "email"
+	VerificationPhone = "phone"
+)
+
 // interface untuk Data Layer
 type UserDataInterface interface {
-	Insert(input User) error
+	Insert(input User) (uint, error)
 	Update(userIdLogin int, input User, photo *multipart.FileHeader) error
 	SelectById(userIdLogin int) (*User, error)
 	Login(phoneOrEmail, password string) (data *User, err error)
 	SelectByNameOrEmail(query string) ([]User, error)
 	UpdateUserByName(name string, input User) error
 	SelectAllUser() ([]User, error)
+	DeletePhoto(userIdLogin int) error
+	UpdatePassword(userId uint, hashedPassword string) error
+	SelectByName(name string) (*User, error)
+	UpdateVerification(userId uint, channel string, verifiedAt *time.Time) error
 }
 
 // interface untuk Service Layer
@@ -32,5 +45,11 @@ type UserServiceInterface interface {
 	Create(input User) error
 	GetById(userIdLogin int) (*User, error)
 	Update(userIdLogin int, input User, photo *multipart.FileHeader) error
-	Login(phoneOrEmail, password string) (data *User, token string, err error)
+	Login(phoneOrEmail, password string, client auth.ClientInfo) (data *User, token *auth.Token, err error)
+	DeletePhoto(userIdLogin int) error
+	RequestPasswordReset(phoneOrEmail string) error
+	ResetPassword(phoneOrEmail, code, newPassword string) error
+	ChangePassword(userIdLogin int, sessionId, currentPassword, newPassword string) error
+	SendVerificationCode(userIdLogin int, channel string) error
+	Verify(userIdLogin int, channel, code string) error
 }
@@ -1,6 +1,8 @@
 package handler
 
 import (
+	"errors"
+	"jastip-jakarta/features/auth"
 	"jastip-jakarta/features/user"
 	"net/http"
 
@@ -75,13 +77,133 @@ func (handler *UserHandler) Login(c echo.Context) error {
 	if errBind != nil {
 		return c.JSON(http.StatusBadRequest, responses.WebResponse("error bind data, data not valid", nil))
 	}
-	result, token, err := handler.userService.Login(reqData.EmailOrPhone, reqData.Password)
+	result, token, err := handler.userService.Login(reqData.EmailOrPhone, reqData.Password, auth.ClientInfo{
+		UserAgent: c.Request().UserAgent(),
+		IPAddress: c.RealIP(),
+	})
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, responses.WebResponse(err.Error(), nil))
+		return c.JSON(loginErrorStatus(err), responses.WebResponse(err.Error(), nil))
 	}
 	responseData := map[string]any{
-		"token": token,
-		"nama":  result.Name,
+		"token":         token.AccessToken,
+		"refresh_token": token.RefreshToken,
+		"expires_at":    token.ExpiresAt,
+		"nama":          result.Name,
 	}
 	return c.JSON(http.StatusOK, responses.WebResponse("Login berhasil", responseData))
 }
+
+func (handler *UserHandler) DeletePhoto(c echo.Context) error {
+	userIdLogin := middlewares.ExtractTokenUserId(c)
+	if userIdLogin == 0 {
+		return c.JSON(http.StatusUnauthorized, responses.WebResponse("Silahkan login terlebih dahulu", nil))
+	}
+
+	err := handler.userService.DeletePhoto(userIdLogin)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, responses.WebResponse(err.Error(), nil))
+	}
+
+	return c.JSON(http.StatusOK, responses.WebResponse("Berhasil menghapus foto profil", nil))
+}
+
+func (handler *UserHandler) ForgotPassword(c echo.Context) error {
+	reqData := ForgotPasswordRequest{}
+	errBind := c.Bind(&reqData)
+	if errBind != nil {
+		return c.JSON(http.StatusBadRequest, responses.WebResponse("error bind data, data not valid", nil))
+	}
+
+	err := handler.userService.RequestPasswordReset(reqData.EmailOrPhone)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, responses.WebResponse(err.Error(), nil))
+	}
+
+	return c.JSON(http.StatusOK, responses.WebResponse("Jika akun terdaftar, kode reset password telah dikirim", nil))
+}
+
+func (handler *UserHandler) ResetPassword(c echo.Context) error {
+	reqData := ResetPasswordRequest{}
+	errBind := c.Bind(&reqData)
+	if errBind != nil {
+		return c.JSON(http.StatusBadRequest, responses.WebResponse("error bind data, data not valid", nil))
+	}
+
+	err := handler.userService.ResetPassword(reqData.EmailOrPhone, reqData.Code, reqData.NewPassword)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, responses.WebResponse(err.Error(), nil))
+	}
+
+	return c.JSON(http.StatusOK, responses.WebResponse("Password berhasil diubah, silahkan login kembali", nil))
+}
+
+func (handler *UserHandler) SendVerificationCode(c echo.Context) error {
+	userIdLogin := middlewares.ExtractTokenUserId(c)
+	if userIdLogin == 0 {
+		return c.JSON(http.StatusUnauthorized, responses.WebResponse("Silahkan login terlebih dahulu", nil))
+	}
+
+	reqData := VerificationRequest{}
+	errBind := c.Bind(&reqData)
+	if errBind != nil {
+		return c.JSON(http.StatusBadRequest, responses.WebResponse("error bind data, data not valid", nil))
+	}
+
+	err := handler.userService.SendVerificationCode(userIdLogin, reqData.Channel)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, responses.WebResponse(err.Error(), nil))
+	}
+
+	return c.JSON(http.StatusOK, responses.WebResponse("Kode verifikasi telah dikirim", nil))
+}
+
+func (handler *UserHandler) Verify(c echo.Context) error {
+	userIdLogin := middlewares.ExtractTokenUserId(c)
+	if userIdLogin == 0 {
+		return c.JSON(http.StatusUnauthorized, responses.WebResponse("Silahkan login terlebih dahulu", nil))
+	}
+
+	reqData := VerificationRequest{}
+	errBind := c.Bind(&reqData)
+	if errBind != nil {
+		return c.JSON(http.StatusBadRequest, responses.WebResponse("error bind data, data not valid", nil))
+	}
+
+	err := handler.userService.Verify(userIdLogin, reqData.Channel, reqData.Code)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, responses.WebResponse(err.Error(), nil))
+	}
+
+	return c.JSON(http.StatusOK, responses.WebResponse("Verifikasi berhasil", nil))
+}
+
+func loginErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, auth.ErrLoginLocked):
+		return http.StatusTooManyRequests
+	case errors.Is(err, auth.ErrInvalidCredentials):
+		return http.StatusUnauthorized
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func (handler *UserHandler) ChangePassword(c echo.Context) error {
+	userIdLogin := middlewares.ExtractTokenUserId(c)
+	if userIdLogin == 0 {
+		return c.JSON(http.StatusUnauthorized, responses.WebResponse("Silahkan login terlebih dahulu", nil))
+	}
+
+	reqData := ChangePasswordRequest{}
+	errBind := c.Bind(&reqData)
+	if errBind != nil {
+		return c.JSON(http.StatusBadRequest, responses.WebResponse("error bind data, data not valid", nil))
+	}
+
+	err := handler.userService.ChangePassword(userIdLogin, middlewares.ExtractTokenSessionId(c), reqData.CurrentPassword, reqData.NewPassword)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, responses.WebResponse(err.Error(), nil))
+	}
+
+	return c.JSON(http.StatusOK, responses.WebResponse("Password berhasil diubah, perangkat lain telah dikeluarkan", nil))
+}
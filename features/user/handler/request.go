@@ -27,6 +27,26 @@ type LoginRequest struct {
 	Password     string `json:"password" form:"password"`
 }
 
+type ForgotPasswordRequest struct {
+	EmailOrPhone string `json:"email_or_phone" form:"email_or_phone"`
+}
+
+type VerificationRequest struct {
+	Channel string `json:"channel" form:"channel"`
+	Code    string `json:"code" form:"code"`
+}
+
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" form:"current_password"`
+	NewPassword     string `json:"new_password" form:"new_password"`
+}
+
+type ResetPasswordRequest struct {
+	EmailOrPhone string `json:"email_or_phone" form:"email_or_phone"`
+	Code         string `json:"code" form:"code"`
+	NewPassword  string `json:"new_password" form:"new_password"`
+}
+
 func RequestToUser(input UserRequest) user.User {
 	return user.User{
 		ID:          generateID(),
@@ -6,13 +6,15 @@ import (
 )
 
 type UserResponse struct {
-	ID           uint   `json:"user_id"`
-	Name         string `json:"name"`
-	Email        string `json:"email"`
-	PhoneNumber  int    `json:"phone_number"`
-	PhotoProfile string `json:"photo_profile"`
-	CreatedAt    string `json:"create_account"`
-	UpdatedAt    string `json:"last_update"`
+	ID            uint   `json:"user_id"`
+	Name          string `json:"name"`
+	Email         string `json:"email"`
+	PhoneNumber   int    `json:"phone_number"`
+	PhotoProfile  string `json:"photo_profile"`
+	EmailVerified bool   `json:"email_verified"`
+	PhoneVerified bool   `json:"phone_verified"`
+	CreatedAt     string `json:"create_account"`
+	UpdatedAt     string `json:"last_update"`
 }
 
 type UserResponseOrder struct {
@@ -21,12 +23,14 @@ type UserResponseOrder struct {
 
 func UserToResponse(data *user.User) UserResponse {
 	return UserResponse{
-		ID:           data.ID,
-		Name:         data.Name,
-		Email:        data.Email,
-		PhoneNumber:  data.PhoneNumber,
-		PhotoProfile: data.PhotoProfile,
-		CreatedAt:    time.FormatDateToIndonesian(data.CreatedAt),
-		UpdatedAt:    time.FormatDateToIndonesian(data.UpdatedAt),
+		ID:            data.ID,
+		Name:          data.Name,
+		Email:         data.Email,
+		PhoneNumber:   data.PhoneNumber,
+		PhotoProfile:  data.PhotoProfile,
+		EmailVerified: data.EmailVerifiedAt != nil,
+		PhoneVerified: data.PhoneVerifiedAt != nil,
+		CreatedAt:     time.FormatDateToIndonesian(data.CreatedAt),
+		UpdatedAt:     time.FormatDateToIndonesian(data.UpdatedAt),
 	}
 }
\ No newline at end of file
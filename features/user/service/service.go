@@ -2,22 +2,30 @@ package service
 
 import (
 	"errors"
+	"jastip-jakarta/features/auth"
 	"jastip-jakarta/features/user"
 	"jastip-jakarta/utils/encrypts"
 	"jastip-jakarta/utils/middlewares"
+	"jastip-jakarta/utils/sender"
+	"log"
 	"mime/multipart"
+	"time"
 )
 
 type userService struct {
 	userData    user.UserDataInterface
 	hashService encrypts.HashInterface
+	authService auth.AuthServiceInterface
+	sender      sender.SenderInterface
 }
 
 // dependency injection
-func New(repo user.UserDataInterface, hash encrypts.HashInterface) user.UserServiceInterface {
+func New(repo user.UserDataInterface, hash encrypts.HashInterface, authService auth.AuthServiceInterface, messageSender sender.SenderInterface) user.UserServiceInterface {
 	return &userService{
 		userData:    repo,
 		hashService: hash,
+		authService: authService,
+		sender:      messageSender,
 	}
 }
 
@@ -41,8 +49,20 @@ func (u *userService) Create(input user.User) error {
 		input.Password = hashedPass
 	}
 
-	err := u.userData.Insert(input)
-	return err
+	userId, err := u.userData.Insert(input)
+	if err != nil {
+		return err
+	}
+	input.ID = userId
+
+	// akun baru belum terverifikasi, kegagalan kirim kode tidak membatalkan pendaftaran
+	// karena kode bisa diminta ulang setelah login
+	for _, channel := range []string{user.VerificationPhone, user.VerificationEmail} {
+		if err := u.sendVerificationCode(input, channel); err != nil {
+			log.Printf("error sending %s verification to user %d: %v", channel, input.ID, err)
+		}
+	}
+	return nil
 }
 
 // GetById implements user.UserServiceInterface.
@@ -55,28 +75,48 @@ func (u *userService) GetById(userIdLogin int) (*user.User, error) {
 }
 
 // Login implements user.UserServiceInterface.
-func (u *userService) Login(phoneOrEmail string, password string) (data *user.User, token string, err error) {
+func (u *userService) Login(phoneOrEmail string, password string, client auth.ClientInfo) (data *user.User, token *auth.Token, err error) {
 	// Validasi jika email atau password kosong
 	if phoneOrEmail == "" {
-		return nil, "", errors.New("Email atau nomor telepon tidak boleh kosong")
+		return nil, nil, errors.New("Email atau nomor telepon tidak boleh kosong")
 	}
 	if password == "" {
-		return nil, "", errors.New("Password tidak boleh kosong")
+		return nil, nil, errors.New("Password tidak boleh kosong")
 	}
 
-	data, err = u.userData.Login(phoneOrEmail, password)
+	data, errLookup := u.userData.Login(phoneOrEmail, password)
+	var userId uint
+	if errLookup == nil {
+		userId = data.ID
+	}
+	identifier := auth.AccountIdentifier(userId, phoneOrEmail)
+
+	err = u.authService.CheckLogin(middlewares.AudienceUser, identifier, client.IPAddress)
 	if err != nil {
-		return nil, "", err
+		return nil, nil, err
 	}
 
-	isValid := u.hashService.CheckPasswordHash(data.Password, password)
-	if !isValid {
-		return nil, "", errors.New("Sandi Salah")
+	// bcrypt tetap dijalankan untuk akun yang tidak ada agar waktu respon tidak membocorkan akun yang terdaftar
+	passwordHash := encrypts.DummyHash
+	if errLookup == nil {
+		passwordHash = data.Password
+	}
+	if !u.hashService.CheckPasswordHash(passwordHash, password) || errLookup != nil {
+		errRecord := u.authService.RecordLoginFailure(middlewares.AudienceUser, identifier, client.IPAddress)
+		if errRecord != nil {
+			return nil, nil, errRecord
+		}
+		return nil, nil, auth.ErrInvalidCredentials
 	}
 
-	token, errJwt := middlewares.CreateToken(int(data.ID))
-	if errJwt != nil {
-		return nil, "", errJwt
+	errReset := u.authService.RecordLoginSuccess(middlewares.AudienceUser, identifier)
+	if errReset != nil {
+		log.Println("gagal mereset percobaan login:", errReset)
+	}
+
+	token, errToken := u.authService.CreateSession(data.ID, middlewares.AudienceUser, client)
+	if errToken != nil {
+		return nil, nil, errToken
 	}
 
 	return data, token, err
@@ -86,4 +126,167 @@ func (u *userService) Login(phoneOrEmail string, password string) (data *user.Us
 func (u *userService) Update(userIdLogin int, input user.User, photo *multipart.FileHeader) error {
 	err := u.userData.Update(userIdLogin, input, photo)
 	return err
-}
\ No newline at end of file
+}
+
+// DeletePhoto implements user.UserServiceInterface.
+func (u *userService) DeletePhoto(userIdLogin int) error {
+	return u.userData.DeletePhoto(userIdLogin)
+}
+
+// RequestPasswordReset implements user.UserServiceInterface.
+// Akun yang tidak terdaftar tidak menghasilkan error agar keberadaan akun tidak bisa ditebak.
+func (u *userService) RequestPasswordReset(phoneOrEmail string) error {
+	if phoneOrEmail == "" {
+		return errors.New("Email atau nomor telepon tidak boleh kosong")
+	}
+
+	data, err := u.userData.Login(phoneOrEmail, "")
+	if err != nil {
+		return nil
+	}
+
+	code, err := u.authService.IssueCode(data.ID, middlewares.AudienceUser, auth.PurposePasswordReset)
+	if errors.Is(err, auth.ErrCodeCooldown) || errors.Is(err, auth.ErrCodeLocked) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	return u.sender.Send(sender.Message{
+		Phone:   sender.FormatPhoneNumber(data.PhoneNumber),
+		Email:   data.Email,
+		Subject: "Kode reset password",
+		Body:    "Kode reset password Jastip Jakarta anda: " + code + ". Jangan berikan kode ini kepada siapapun.",
+	})
+}
+
+// ResetPassword implements user.UserServiceInterface.
+func (u *userService) ResetPassword(phoneOrEmail, code, newPassword string) error {
+	if phoneOrEmail == "" || code == "" {
+		return errors.New("kode tidak valid")
+	}
+	if err := encrypts.ValidatePassword(newPassword); err != nil {
+		return err
+	}
+
+	data, err := u.userData.Login(phoneOrEmail, "")
+	if err != nil {
+		return errors.New("kode tidak valid")
+	}
+
+	err = u.authService.VerifyCode(data.ID, middlewares.AudienceUser, auth.PurposePasswordReset, code)
+	if err != nil {
+		return err
+	}
+
+	hashedPass, errHash := u.hashService.HashPassword(newPassword)
+	if errHash != nil {
+		return errors.New("Error hash password.")
+	}
+
+	err = u.userData.UpdatePassword(data.ID, hashedPass)
+	if err != nil {
+		return err
+	}
+
+	// semua perangkat yang login dengan password lama dikeluarkan
+	return u.authService.LogoutAll(data.ID, middlewares.AudienceUser)
+}
+
+// ChangePassword implements user.UserServiceInterface.
+// Perangkat lain yang login dengan password lama dikeluarkan, session yang sedang dipakai tetap aktif.
+func (u *userService) ChangePassword(userIdLogin int, sessionId, currentPassword, newPassword string) error {
+	if currentPassword == "" {
+		return errors.New("password lama tidak boleh kosong")
+	}
+	if err := encrypts.ValidatePassword(newPassword); err != nil {
+		return err
+	}
+
+	data, err := u.userData.SelectById(userIdLogin)
+	if err != nil {
+		return err
+	}
+	if !u.hashService.CheckPasswordHash(data.Password, currentPassword) {
+		return errors.New("password lama salah")
+	}
+	if u.hashService.CheckPasswordHash(data.Password, newPassword) {
+		return errors.New("password baru tidak boleh sama dengan password lama")
+	}
+
+	hashedPass, errHash := u.hashService.HashPassword(newPassword)
+	if errHash != nil {
+		return errors.New("Error hash password.")
+	}
+
+	err = u.userData.UpdatePassword(data.ID, hashedPass)
+	if err != nil {
+		return err
+	}
+	return u.authService.LogoutOthers(data.ID, middlewares.AudienceUser, sessionId)
+}
+
+// verificationPurpose memetakan saluran verifikasi ke kegunaan kode OTP.
+func verificationPurpose(channel string) (string, error) {
+	switch channel {
+	case user.VerificationEmail:
+		return auth.PurposeVerifyEmail, nil
+	case user.VerificationPhone:
+		return auth.PurposeVerifyPhone, nil
+	default:
+		return "", errors.New("jenis verifikasi harus email atau phone")
+	}
+}
+
+func (u *userService) sendVerificationCode(data user.User, channel string) error {
+	purpose, err := verificationPurpose(channel)
+	if err != nil {
+		return err
+	}
+
+	code, err := u.authService.IssueCode(data.ID, middlewares.AudienceUser, purpose)
+	if err != nil {
+		return err
+	}
+
+	msg := sender.Message{Body: "Kode verifikasi Jastip Jakarta anda: " + code + ". Jangan berikan kode ini kepada siapapun."}
+	if channel == user.VerificationEmail {
+		msg.Email = data.Email
+		msg.Subject = "Kode verifikasi email"
+	} else {
+		msg.Phone = sender.FormatPhoneNumber(data.PhoneNumber)
+		msg.Subject = "Kode verifikasi nomor WhatsApp"
+	}
+	return u.sender.Send(msg)
+}
+
+// SendVerificationCode implements user.UserServiceInterface.
+func (u *userService) SendVerificationCode(userIdLogin int, channel string) error {
+	data, err := u.userData.SelectById(userIdLogin)
+	if err != nil {
+		return err
+	}
+
+	if (channel == user.VerificationEmail && data.EmailVerifiedAt != nil) ||
+		(channel == user.VerificationPhone && data.PhoneVerifiedAt != nil) {
+		return errors.New("akun sudah terverifikasi")
+	}
+	return u.sendVerificationCode(*data, channel)
+}
+
+// Verify implements user.UserServiceInterface.
+func (u *userService) Verify(userIdLogin int, channel, code string) error {
+	purpose, err := verificationPurpose(channel)
+	if err != nil {
+		return err
+	}
+
+	err = u.authService.VerifyCode(uint(userIdLogin), middlewares.AudienceUser, purpose, code)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	return u.userData.UpdateVerification(uint(userIdLogin), channel, &now)
+}
@@ -1,6 +1,8 @@
 package main
 
 import (
+	"log"
+
 	"jastip-jakarta/app/config"
 	"jastip-jakarta/app/database"
 	"jastip-jakarta/app/router"
@@ -15,6 +17,11 @@ func main() {
 	dbSql := database.InitDBMysql(cfg)
 
 	e := echo.New()
+	ipExtractor, err := router.IPExtractor()
+	if err != nil {
+		log.Fatal("error init ip extractor : ", err.Error())
+	}
+	e.IPExtractor = ipExtractor
 	e.Use(middleware.CORS())
 	e.Pre(middleware.RemoveTrailingSlash())
 
@@ -6,6 +6,11 @@ import (
 	"golang.org/x/crypto/bcrypt"
 )
 
+// DummyHash adalah hash bcrypt dengan cost yang sama dengan HashPassword. Dipakai untuk
+// tetap menjalankan CheckPasswordHash saat akun tidak ditemukan, agar waktu respon login
+// tidak membedakan akun yang terdaftar dan yang tidak.
+const DummyHash = "$2a$10$JHb5QO9XyJHDPTFJCLxHpew1dQBvT6/OmOQlf6ceEFRTCR40cPWa2"
+
 type HashInterface interface {
 	CheckPasswordHash(hashed string, input string) bool
 	HashPassword(input string) (string, error)
@@ -0,0 +1,37 @@
+package encrypts
+
+import (
+	"errors"
+	"unicode"
+)
+
+// batas panjang password, bcrypt hanya memakai 72 byte pertama
+const (
+	PasswordMinLength = 8
+	PasswordMaxLength = 72
+)
+
+// ValidatePassword mengecek password baru terhadap kebijakan password aplikasi:
+// minimal 8 karakter, maksimal 72 byte, serta mengandung huruf dan angka.
+func ValidatePassword(password string) error {
+	if len([]rune(password)) < PasswordMinLength {
+		return errors.New("password minimal 8 karakter")
+	}
+	if len(password) > PasswordMaxLength {
+		return errors.New("password terlalu panjang")
+	}
+
+	var hasLetter, hasDigit bool
+	for _, r := range password {
+		switch {
+		case unicode.IsLetter(r):
+			hasLetter = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		}
+	}
+	if !hasLetter || !hasDigit {
+		return errors.New("password harus mengandung huruf dan angka")
+	}
+	return nil
+}
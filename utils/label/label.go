@@ -0,0 +1,248 @@
+package label
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"os"
+
+	"github.com/boombuler/barcode"
+	"github.com/boombuler/barcode/code128"
+	"github.com/boombuler/barcode/qr"
+	"github.com/jung-kurt/gofpdf"
+	xdraw "golang.org/x/image/draw"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+)
+
+type Label struct {
+	Code       string
+	Title      string
+	Batch      string
+	RegionCode string
+	Recipient  string
+	Weight     float64
+}
+
+type LabelGeneratorInterface interface {
+	GeneratePNG(filePath string, data Label) error
+	GeneratePDF(filePath string, size string, data []Label) error
+}
+
+type LabelGenerator struct {
+}
+
+func New() LabelGeneratorInterface {
+	return &LabelGenerator{}
+}
+
+const (
+	pngWidth  = 800
+	pngHeight = 560
+)
+
+// GeneratePNG implements LabelGeneratorInterface.
+func (l *LabelGenerator) GeneratePNG(filePath string, data Label) error {
+	img := image.NewRGBA(image.Rect(0, 0, pngWidth, pngHeight))
+	draw.Draw(img, img.Bounds(), &image.Uniform{color.White}, image.Point{}, draw.Src)
+
+	barcodeImg, err := encodeCode128(data.Code, pngWidth-80, 140)
+	if err != nil {
+		return err
+	}
+	qrImg, err := encodeQR(data.Code, 200)
+	if err != nil {
+		return err
+	}
+
+	drawText(img, 40, 30, data.Title, 3)
+	draw.Draw(img, image.Rect(40, 90, 40+barcodeImg.Bounds().Dx(), 90+barcodeImg.Bounds().Dy()), barcodeImg, image.Point{}, draw.Src)
+	drawText(img, 40, 240, data.Code, 2)
+
+	drawText(img, 40, 300, data.RegionCode, 6)
+	drawText(img, 40, 420, "Penerima: "+data.Recipient, 2)
+	drawText(img, 40, 460, fmt.Sprintf("Berat: %.2f kg", data.Weight), 2)
+	if data.Batch != "" {
+		drawText(img, 40, 500, "Batch: "+data.Batch, 2)
+	}
+	draw.Draw(img, image.Rect(pngWidth-240, pngHeight-240, pngWidth-40, pngHeight-40), qrImg, image.Point{}, draw.Src)
+
+	file, err := os.Create(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer file.Close()
+
+	err = png.Encode(file, img)
+	if err != nil {
+		return fmt.Errorf("failed to encode png: %w", err)
+	}
+	return nil
+}
+
+// GeneratePDF implements LabelGeneratorInterface.
+// Ukuran "a6" mencetak satu label per halaman, "a4" mencetak delapan label per halaman.
+func (l *LabelGenerator) GeneratePDF(filePath string, size string, data []Label) error {
+	var pdf *gofpdf.Fpdf
+	var cols, rows int
+	var labelWidth, labelHeight float64
+
+	switch size {
+	case "a6":
+		pdf = gofpdf.New("P", "mm", "A6", "")
+		cols, rows = 1, 1
+		labelWidth, labelHeight = 105, 148
+	case "a4":
+		pdf = gofpdf.New("P", "mm", "A4", "")
+		cols, rows = 2, 4
+		labelWidth, labelHeight = 105, 74.25
+	default:
+		return fmt.Errorf("invalid label size: %s", size)
+	}
+
+	pdf.SetMargins(0, 0, 0)
+	pdf.SetAutoPageBreak(false, 0)
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+
+	perPage := cols * rows
+	for i, item := range data {
+		if i%perPage == 0 {
+			pdf.AddPage()
+		}
+
+		pos := i % perPage
+		x := float64(pos%cols) * labelWidth
+		y := float64(pos/cols) * labelHeight
+
+		err := drawPDFLabel(pdf, tr, fmt.Sprintf("label-%d", i), x, y, labelWidth, labelHeight, item)
+		if err != nil {
+			return err
+		}
+	}
+
+	err := pdf.OutputFileAndClose(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to write pdf: %w", err)
+	}
+	return nil
+}
+
+func drawPDFLabel(pdf *gofpdf.Fpdf, tr func(string) string, name string, x, y, w, h float64, data Label) error {
+	barcodeImg, err := encodeCode128(data.Code, 600, 120)
+	if err != nil {
+		return err
+	}
+	qrImg, err := encodeQR(data.Code, 300)
+	if err != nil {
+		return err
+	}
+
+	if err := registerPNG(pdf, name+"-barcode", barcodeImg); err != nil {
+		return err
+	}
+	if err := registerPNG(pdf, name+"-qr", qrImg); err != nil {
+		return err
+	}
+
+	pad := 4.0
+	pdf.SetDrawColor(0, 0, 0)
+	pdf.Rect(x+1, y+1, w-2, h-2, "D")
+
+	// Tinggi komponen menyesuaikan ukuran label
+	barcodeHeight := h * 0.2
+	qrSize := h * 0.35
+	if qrSize > w*0.4 {
+		qrSize = w * 0.4
+	}
+
+	pdf.SetXY(x+pad, y+pad)
+	pdf.SetFont("Arial", "B", 10)
+	pdf.CellFormat(w-2*pad, 5, tr(data.Title), "", 2, "L", false, 0, "")
+
+	pdf.ImageOptions(name+"-barcode", x+pad, y+pad+6, w-2*pad, barcodeHeight, false, gofpdf.ImageOptions{ImageType: "PNG"}, 0, "")
+	pdf.SetXY(x+pad, y+pad+7+barcodeHeight)
+	pdf.SetFont("Arial", "", 9)
+	pdf.CellFormat(w-2*pad, 4, data.Code, "", 2, "C", false, 0, "")
+
+	textY := y + pad + 13 + barcodeHeight
+	pdf.SetXY(x+pad, textY)
+	pdf.SetFont("Arial", "B", 20)
+	pdf.CellFormat(w-qrSize-3*pad, 9, tr(data.RegionCode), "", 2, "L", false, 0, "")
+	pdf.SetFont("Arial", "", 9)
+	pdf.CellFormat(w-qrSize-3*pad, 5, tr("Penerima: "+data.Recipient), "", 2, "L", false, 0, "")
+	pdf.CellFormat(w-qrSize-3*pad, 5, fmt.Sprintf("Berat: %.2f kg", data.Weight), "", 2, "L", false, 0, "")
+	if data.Batch != "" {
+		pdf.CellFormat(w-qrSize-3*pad, 5, "Batch: "+data.Batch, "", 2, "L", false, 0, "")
+	}
+
+	pdf.ImageOptions(name+"-qr", x+w-pad-qrSize, y+h-pad-qrSize, qrSize, qrSize, false, gofpdf.ImageOptions{ImageType: "PNG"}, 0, "")
+	return nil
+}
+
+func registerPNG(pdf *gofpdf.Fpdf, name string, img image.Image) error {
+	// gofpdf tidak mendukung PNG 16-bit, jadi gambar dikonversi ke grayscale 8-bit
+	gray := image.NewGray(img.Bounds())
+	draw.Draw(gray, gray.Bounds(), img, img.Bounds().Min, draw.Src)
+
+	var buf bytes.Buffer
+	err := png.Encode(&buf, gray)
+	if err != nil {
+		return fmt.Errorf("failed to encode png: %w", err)
+	}
+	pdf.RegisterImageOptionsReader(name, gofpdf.ImageOptions{ImageType: "PNG"}, &buf)
+	return pdf.Error()
+}
+
+func encodeCode128(content string, width, height int) (barcode.Barcode, error) {
+	bc, err := code128.Encode(content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode barcode: %w", err)
+	}
+	if width < bc.Bounds().Dx() {
+		width = bc.Bounds().Dx()
+	}
+	scaled, err := barcode.Scale(bc, width, height)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scale barcode: %w", err)
+	}
+	return scaled, nil
+}
+
+func encodeQR(content string, size int) (barcode.Barcode, error) {
+	qrCode, err := qr.Encode(content, qr.M, qr.Auto)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode qr code: %w", err)
+	}
+	scaled, err := barcode.Scale(qrCode, size, size)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scale qr code: %w", err)
+	}
+	return scaled, nil
+}
+
+// drawText menulis teks dengan font bawaan lalu memperbesarnya sesuai skala.
+func drawText(dst draw.Image, x, y int, text string, scale int) {
+	face := basicfont.Face7x13
+	width := font.MeasureString(face, text).Ceil()
+	if width == 0 {
+		return
+	}
+	height := face.Metrics().Height.Ceil()
+
+	src := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(src, src.Bounds(), &image.Uniform{color.White}, image.Point{}, draw.Src)
+	drawer := font.Drawer{
+		Dst:  src,
+		Src:  image.NewUniform(color.Black),
+		Face: face,
+		Dot:  fixed.P(0, face.Metrics().Ascent.Ceil()),
+	}
+	drawer.DrawString(text)
+
+	target := image.Rect(x, y, x+width*scale, y+height*scale)
+	xdraw.NearestNeighbor.Scale(dst, target, src, src.Bounds(), draw.Over, nil)
+}
@@ -0,0 +1,196 @@
+package manifest
+
+import (
+	"fmt"
+	"html/template"
+	"os"
+
+	"github.com/jung-kurt/gofpdf"
+)
+
+type ManifestOrder struct {
+	NomorOrder           string
+	NamaPenerima         string
+	NomorTeleponWhatsapp string
+	NamaBarang           string
+	NomorResi            string
+	NomorResiJastip      string
+	Berat                float64
+	Harga                int
+}
+
+type Manifest struct {
+	BatchPengiriman     string
+	KodeWilayah         string
+	Wilayah             string
+	AlamatWilayah       string
+	HargaPerKodeWilayah int
+	TanggalCetak        string
+	AdminJakarta        string
+	AdminPerwakilan     string
+	TotalOrder          int
+	TotalBerat          float64
+	TotalHarga          int
+	Orders              []ManifestOrder
+}
+
+type ManifestGeneratorInterface interface {
+	GenerateHTML(filePath string, data Manifest) error
+	GeneratePDF(filePath string, data Manifest) error
+}
+
+type ManifestGenerator struct {
+}
+
+func New() ManifestGeneratorInterface {
+	return &ManifestGenerator{}
+}
+
+var htmlTemplate = template.Must(template.New("manifest").Funcs(template.FuncMap{
+	"inc":    func(i int) int { return i + 1 },
+	"weight": func(w float64) string { return fmt.Sprintf("%.2f", w) },
+}).Parse(`<!DOCTYPE html>
+<html lang="id">
+<head>
+<meta charset="utf-8">
+<title>Manifest {{.BatchPengiriman}} - {{.KodeWilayah}}</title>
+<style>
+body { font-family: Arial, sans-serif; font-size: 12px; margin: 24px; }
+h1 { font-size: 18px; margin-bottom: 4px; }
+table { width: 100%; border-collapse: collapse; margin-top: 12px; }
+th, td { border: 1px solid #000; padding: 4px 6px; text-align: left; }
+td.num { text-align: right; }
+.info td { border: none; padding: 2px 6px 2px 0; }
+.signature { width: 100%; margin-top: 48px; }
+.signature td { border: none; width: 50%; text-align: center; vertical-align: top; }
+.signature .line { margin-top: 64px; border-top: 1px solid #000; display: inline-block; width: 60%; padding-top: 4px; }
+@media print { body { margin: 0; } }
+</style>
+</head>
+<body>
+<h1>Manifest Pengiriman Jastip Jakarta</h1>
+<table class="info">
+<tr><td>Batch Pengiriman</td><td>: {{.BatchPengiriman}}</td></tr>
+<tr><td>Kode Wilayah</td><td>: {{.KodeWilayah}} - {{.Wilayah}}</td></tr>
+<tr><td>Alamat Perwakilan</td><td>: {{.AlamatWilayah}}</td></tr>
+<tr><td>Tanggal Cetak</td><td>: {{.TanggalCetak}}</td></tr>
+</table>
+<table>
+<thead>
+<tr><th>No</th><th>Nomor Order</th><th>Penerima</th><th>Nomor WhatsApp</th><th>Nama Barang</th><th>Nomor Resi</th><th>Nomor Resi Jastip</th><th>Berat (kg)</th><th>Harga</th></tr>
+</thead>
+<tbody>
+{{range $i, $o := .Orders}}<tr><td>{{inc $i}}</td><td>{{$o.NomorOrder}}</td><td>{{$o.NamaPenerima}}</td><td>{{$o.NomorTeleponWhatsapp}}</td><td>{{$o.NamaBarang}}</td><td>{{$o.NomorResi}}</td><td>{{$o.NomorResiJastip}}</td><td class="num">{{weight $o.Berat}}</td><td class="num">{{$o.Harga}}</td></tr>
+{{end}}</tbody>
+<tfoot>
+<tr><th colspan="7">Total ({{.TotalOrder}} order)</th><th class="num">{{weight .TotalBerat}}</th><th class="num">{{.TotalHarga}}</th></tr>
+</tfoot>
+</table>
+<table class="signature">
+<tr>
+<td>Diserahkan oleh,<br>Admin Jakarta<br><span class="line">{{.AdminJakarta}}</span></td>
+<td>Diterima oleh,<br>Perwakilan {{.Wilayah}}<br><span class="line">{{.AdminPerwakilan}}</span></td>
+</tr>
+</table>
+</body>
+</html>
+`))
+
+// GenerateHTML implements ManifestGeneratorInterface.
+func (m *ManifestGenerator) GenerateHTML(filePath string, data Manifest) error {
+	file, err := os.Create(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer file.Close()
+
+	err = htmlTemplate.Execute(file, data)
+	if err != nil {
+		return fmt.Errorf("failed to render manifest: %w", err)
+	}
+	return nil
+}
+
+// GeneratePDF implements ManifestGeneratorInterface.
+func (m *ManifestGenerator) GeneratePDF(filePath string, data Manifest) error {
+	pdf := gofpdf.New("L", "mm", "A4", "")
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+	pdf.SetMargins(10, 10, 10)
+	pdf.AddPage()
+
+	pdf.SetFont("Arial", "B", 14)
+	pdf.CellFormat(0, 8, "Manifest Pengiriman Jastip Jakarta", "", 1, "L", false, 0, "")
+
+	pdf.SetFont("Arial", "", 10)
+	info := [][2]string{
+		{"Batch Pengiriman", data.BatchPengiriman},
+		{"Kode Wilayah", data.KodeWilayah + " - " + data.Wilayah},
+		{"Alamat Perwakilan", data.AlamatWilayah},
+		{"Tanggal Cetak", data.TanggalCetak},
+	}
+	for _, row := range info {
+		pdf.CellFormat(40, 6, row[0], "", 0, "L", false, 0, "")
+		pdf.CellFormat(0, 6, ": "+tr(row[1]), "", 1, "L", false, 0, "")
+	}
+	pdf.Ln(4)
+
+	header := []string{"No", "Nomor Order", "Penerima", "Nomor WhatsApp", "Nama Barang", "Nomor Resi", "Resi Jastip", "Berat (kg)", "Harga"}
+	widths := []float64{10, 38, 38, 32, 50, 32, 32, 20, 25}
+
+	pdf.SetFont("Arial", "B", 9)
+	pdf.SetFillColor(230, 230, 230)
+	for i, title := range header {
+		pdf.CellFormat(widths[i], 7, title, "1", 0, "C", true, 0, "")
+	}
+	pdf.Ln(-1)
+
+	pdf.SetFont("Arial", "", 9)
+	for i, order := range data.Orders {
+		row := []string{
+			fmt.Sprintf("%d", i+1),
+			order.NomorOrder,
+			tr(order.NamaPenerima),
+			order.NomorTeleponWhatsapp,
+			tr(order.NamaBarang),
+			order.NomorResi,
+			order.NomorResiJastip,
+			fmt.Sprintf("%.2f", order.Berat),
+			fmt.Sprintf("%d", order.Harga),
+		}
+		for j, value := range row {
+			align := "L"
+			if j == 0 || j >= 7 {
+				align = "R"
+			}
+			pdf.CellFormat(widths[j], 6, value, "1", 0, align, false, 0, "")
+		}
+		pdf.Ln(-1)
+	}
+
+	pdf.SetFont("Arial", "B", 9)
+	totalWidth := 0.0
+	for _, w := range widths[:7] {
+		totalWidth += w
+	}
+	pdf.CellFormat(totalWidth, 7, fmt.Sprintf("Total (%d order)", data.TotalOrder), "1", 0, "L", true, 0, "")
+	pdf.CellFormat(widths[7], 7, fmt.Sprintf("%.2f", data.TotalBerat), "1", 0, "R", true, 0, "")
+	pdf.CellFormat(widths[8], 7, fmt.Sprintf("%d", data.TotalHarga), "1", 1, "R", true, 0, "")
+
+	// Blok tanda tangan admin Jakarta dan perwakilan
+	pdf.Ln(10)
+	pdf.SetFont("Arial", "", 10)
+	half := 138.5
+	pdf.CellFormat(half, 6, "Diserahkan oleh,", "", 0, "C", false, 0, "")
+	pdf.CellFormat(half, 6, "Diterima oleh,", "", 1, "C", false, 0, "")
+	pdf.CellFormat(half, 6, "Admin Jakarta", "", 0, "C", false, 0, "")
+	pdf.CellFormat(half, 6, tr("Perwakilan "+data.Wilayah), "", 1, "C", false, 0, "")
+	pdf.Ln(20)
+	pdf.CellFormat(half, 6, tr("( "+data.AdminJakarta+" )"), "", 0, "C", false, 0, "")
+	pdf.CellFormat(half, 6, tr("( "+data.AdminPerwakilan+" )"), "", 1, "C", false, 0, "")
+
+	err := pdf.OutputFileAndClose(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to write pdf: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package middlewares
+
+import (
+	"jastip-jakarta/utils/responses"
+	"net/http"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/labstack/echo/v4"
+)
+
+// APIKeyHeader adalah header tempat klien mengirim API key.
+const APIKeyHeader = "X-API-Key"
+
+// APIKeyChecker dipenuhi oleh service yang menyimpan API key.
+type APIKeyChecker interface {
+	Authenticate(rawKey string) (subjectId uint, audience string, scopes []string, err error)
+}
+
+var apiKeyChecker APIKeyChecker
+
+// SetAPIKeyChecker memasang validasi API key untuk route yang menerima API key.
+func SetAPIKeyChecker(checker APIKeyChecker) {
+	apiKeyChecker = checker
+}
+
+// UserJWTOrAPIKey menerima token user atau API key milik user yang memiliki scope.
+func UserJWTOrAPIKey(scope string) echo.MiddlewareFunc {
+	return jwtOrAPIKey(AudienceUser, scope)
+}
+
+// AdminJWTOrAPIKey menerima token admin atau API key milik admin yang memiliki scope.
+// Permission admin pemilik key tetap dicek oleh Authorizer.
+func AdminJWTOrAPIKey(scope string) echo.MiddlewareFunc {
+	return jwtOrAPIKey(AudienceAdmin, scope)
+}
+
+// jwtOrAPIKey memakai API key jika header-nya ada, selain itu diteruskan ke JWT middleware.
+// Request dengan API key diberi token sintetis tanpa session sehingga ExtractTokenUserId
+// dan ExtractTokenAdminId tetap bekerja di handler.
+func jwtOrAPIKey(audience, scope string) echo.MiddlewareFunc {
+	withJWT := jwtMiddleware(audience)
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		jwtNext := withJWT(next)
+		return func(c echo.Context) error {
+			rawKey := c.Request().Header.Get(APIKeyHeader)
+			if rawKey == "" {
+				return jwtNext(c)
+			}
+			if apiKeyChecker == nil {
+				return c.JSON(http.StatusUnauthorized, responses.WebResponse("API key tidak valid", nil))
+			}
+
+			subjectId, keyAudience, scopes, err := apiKeyChecker.Authenticate(rawKey)
+			if err != nil || keyAudience != audience {
+				return c.JSON(http.StatusUnauthorized, responses.WebResponse("API key tidak valid", nil))
+			}
+			if !hasScope(scopes, scope) {
+				return c.JSON(http.StatusForbidden, responses.WebResponse("API key tidak memiliki scope "+scope, nil))
+			}
+
+			c.Set("user", &jwt.Token{
+				Claims: jwt.MapClaims{
+					"userId": float64(subjectId),
+					"aud":    audience,
+					"apikey": true,
+				},
+				Valid: true,
+			})
+			return next(c)
+		}
+	}
+}
+
+func hasScope(scopes []string, scope string) bool {
+	for _, s := range scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,156 @@
+package middlewares
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// AuditEntry adalah satu perubahan yang dilakukan admin. Before dan After berisi
+// keadaan entitas sebelum dan sesudah request, nil jika tidak ada snapshot. AdminID 0
+// berarti request tanpa login admin, misalnya setup super admin pertama.
+type AuditEntry struct {
+	AdminID    uint
+	Method     string
+	Path       string
+	EntityType string
+	EntityID   string
+	Before     interface{}
+	After      interface{}
+	StatusCode int
+	IPAddress  string
+	UserAgent  string
+}
+
+// AuditRecorder dipenuhi oleh service yang menyimpan audit log.
+type AuditRecorder interface {
+	RecordAudit(entry AuditEntry) error
+}
+
+// Snapshot mengambil keadaan entitas berdasarkan ID dari parameter route.
+type Snapshot func(entityId string) (interface{}, error)
+
+// Resolver mengubah nilai parameter route (misalnya nama user) menjadi ID yang tidak ikut
+// berubah saat entitas diubah, sehingga snapshot sesudah perubahan tetap menemukan entitasnya.
+type Resolver func(param string) (string, error)
+
+// auditEntityKey menyimpan ID entitas yang baru dibuat oleh handler.
+const auditEntityKey = "audit_entity_id"
+
+// SetAuditEntity dipanggil handler yang membuat data baru agar audit log mencatat ID
+// dan keadaan entitas yang dibuat.
+func SetAuditEntity(c echo.Context, entityId string) {
+	c.Set(auditEntityKey, entityId)
+}
+
+type Auditor struct {
+	recorder AuditRecorder
+}
+
+func NewAuditor(recorder AuditRecorder) *Auditor {
+	return &Auditor{
+		recorder: recorder,
+	}
+}
+
+// Track mencatat request admin yang berhasil mengubah data. Dipasang setelah
+// AdminJWTMiddleware dan Authorizer. Jika snapshot diberikan, entitas dengan ID dari
+// parameter route diambil sebelum dan sesudah handler agar perubahannya bisa dibandingkan.
+func (a *Auditor) Track(entityType, param string, snapshot Snapshot) echo.MiddlewareFunc {
+	return a.track(entityType, snapshot, func(c echo.Context) string {
+		if param == "" {
+			return ""
+		}
+		return c.Param(param)
+	})
+}
+
+// TrackResolved sama dengan Track tetapi parameter route diubah dulu menjadi ID oleh resolve.
+func (a *Auditor) TrackResolved(entityType, param string, resolve Resolver, snapshot Snapshot) echo.MiddlewareFunc {
+	return a.track(entityType, snapshot, func(c echo.Context) string {
+		entityId, err := resolve(c.Param(param))
+		if err != nil {
+			return c.Param(param)
+		}
+		return entityId
+	})
+}
+
+// TrackQuery sama dengan Track untuk route yang entitasnya ditentukan query param, misalnya
+// semua order satu batch dan region. ID entitas adalah nilai param yang digabung dengan ":".
+func (a *Auditor) TrackQuery(entityType string, snapshot Snapshot, params ...string) echo.MiddlewareFunc {
+	return a.track(entityType, snapshot, func(c echo.Context) string {
+		values := make([]string, len(params))
+		for i, param := range params {
+			values[i] = c.QueryParam(param)
+		}
+		return strings.Join(values, ":")
+	})
+}
+
+// TrackSelf sama dengan Track untuk route yang mengubah akun admin yang sedang login.
+func (a *Auditor) TrackSelf(entityType string, snapshot Snapshot) echo.MiddlewareFunc {
+	return a.track(entityType, snapshot, func(c echo.Context) string {
+		return strconv.Itoa(ExtractTokenAdminId(c))
+	})
+}
+
+func (a *Auditor) track(entityType string, snapshot Snapshot, entityIdOf func(c echo.Context) string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			entityId := entityIdOf(c)
+
+			var before interface{}
+			if snapshot != nil && entityId != "" {
+				before = takeSnapshot(snapshot, entityId)
+			}
+
+			if err := next(c); err != nil {
+				c.Error(err)
+			}
+
+			status := c.Response().Status
+			if status >= http.StatusBadRequest {
+				return nil
+			}
+
+			if entityId == "" {
+				entityId, _ = c.Get(auditEntityKey).(string)
+			}
+
+			var after interface{}
+			if snapshot != nil && entityId != "" {
+				after = takeSnapshot(snapshot, entityId)
+			}
+
+			err := a.recorder.RecordAudit(AuditEntry{
+				AdminID:    uint(ExtractTokenAdminId(c)),
+				Method:     c.Request().Method,
+				Path:       c.Path(),
+				EntityType: entityType,
+				EntityID:   entityId,
+				Before:     before,
+				After:      after,
+				StatusCode: status,
+				IPAddress:  c.RealIP(),
+				UserAgent:  c.Request().UserAgent(),
+			})
+			if err != nil {
+				log.Printf("gagal mencatat audit log %s %s: %v", c.Request().Method, c.Path(), err)
+			}
+			return nil
+		}
+	}
+}
+
+// takeSnapshot mengembalikan nil jika entitas tidak ditemukan, misalnya setelah dihapus.
+func takeSnapshot(snapshot Snapshot, entityId string) interface{} {
+	state, err := snapshot(entityId)
+	if err != nil {
+		return nil
+	}
+	return state
+}
@@ -0,0 +1,42 @@
+package middlewares
+
+import (
+	"jastip-jakarta/utils/responses"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// PermissionChecker dipenuhi oleh service yang bisa mengecek permission milik admin.
+type PermissionChecker interface {
+	HasPermission(adminIdLogin int, permission string) (bool, error)
+}
+
+type Authorizer struct {
+	checker PermissionChecker
+}
+
+func NewAuthorizer(checker PermissionChecker) *Authorizer {
+	return &Authorizer{
+		checker: checker,
+	}
+}
+
+// Require menolak request dari admin yang role-nya tidak memiliki permission.
+// Dipasang setelah AdminJWTMiddleware pada route admin.
+func (a *Authorizer) Require(permission string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			adminIdLogin := ExtractTokenAdminId(c)
+			if adminIdLogin == 0 {
+				return c.JSON(http.StatusUnauthorized, responses.WebResponse("Silahkan login terlebih dahulu", nil))
+			}
+
+			allowed, err := a.checker.HasPermission(adminIdLogin, permission)
+			if err != nil || !allowed {
+				return c.JSON(http.StatusForbidden, responses.WebResponse("anda tidak memiliki akses untuk menggunakan fitur ini", nil))
+			}
+			return next(c)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package middlewares
 
 import (
+	"errors"
 	"jastip-jakarta/app/config"
 	"strings"
 	"time"
@@ -10,40 +11,143 @@ import (
 	"github.com/labstack/echo/v4"
 )
 
-func JWTMiddleware() echo.MiddlewareFunc {
+// audience token, membedakan token milik user dan admin yang ID-nya bisa sama
+const (
+	AudienceUser  = "user"
+	AudienceAdmin = "admin"
+)
+
+// SessionChecker dipenuhi oleh service yang menyimpan session login.
+type SessionChecker interface {
+	IsSessionActive(sessionId string) (bool, error)
+}
+
+var sessionChecker SessionChecker
+
+// SetSessionChecker memasang pengecekan pencabutan session untuk semua token.
+func SetSessionChecker(checker SessionChecker) {
+	sessionChecker = checker
+}
+
+// UserJWTMiddleware hanya menerima token yang dibuat untuk user.
+func UserJWTMiddleware() echo.MiddlewareFunc {
+	return jwtMiddleware(AudienceUser)
+}
+
+// AdminJWTMiddleware hanya menerima token yang dibuat untuk admin.
+func AdminJWTMiddleware() echo.MiddlewareFunc {
+	return jwtMiddleware(AudienceAdmin)
+}
+
+func jwtMiddleware(audience string) echo.MiddlewareFunc {
 	return echojwt.WithConfig(echojwt.Config{
-		SigningKey:    []byte(config.JWT_SECRET),
-		SigningMethod: "HS256",
+		ParseTokenFunc: func(c echo.Context, auth string) (interface{}, error) {
+			return parseToken(auth, audience)
+		},
 	})
 }
 
+// parseToken memvalidasi signature, masa berlaku, audience dan session token.
+func parseToken(token, audience string) (*jwt.Token, error) {
+	tokenJWT, err := jwt.Parse(token, func(t *jwt.Token) (interface{}, error) {
+		return []byte(config.JWT_SECRET), nil
+	}, jwt.WithValidMethods([]string{"HS256"}), jwt.WithAudience(audience))
+	if err != nil {
+		return nil, err
+	}
+	if !tokenJWT.Valid {
+		return nil, errors.New("token tidak valid")
+	}
+
+	sessionId, _ := tokenJWT.Claims.(jwt.MapClaims)["sid"].(string)
+	if sessionId == "" {
+		return nil, errors.New("token tidak valid")
+	}
+	if sessionChecker != nil {
+		active, err := sessionChecker.IsSessionActive(sessionId)
+		if err != nil || !active {
+			return nil, errors.New("session sudah berakhir, silahkan login kembali")
+		}
+	}
+	return tokenJWT, nil
+}
+
 // Generate token jwt
-func CreateToken(userId int) (string, error) {
+func CreateToken(userId int, audience, sessionId string, expiresAt time.Time) (string, error) {
 	claims := jwt.MapClaims{}
 	claims["authorized"] = true
 	claims["userId"] = userId
-	claims["exp"] = time.Now().Add(time.Hour * 24 * 7).Unix() // Token expires after 7 days
+	claims["aud"] = audience
+	claims["sid"] = sessionId
+	claims["exp"] = expiresAt.Unix()
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	return token.SignedString([]byte(config.JWT_SECRET))
 
 }
 
-// extract token jwt
+// extract token jwt milik user, token admin dianggap tidak login
 func ExtractTokenUserId(e echo.Context) int {
+	return extractTokenId(e, AudienceUser)
+}
+
+// extract token jwt milik admin, token user dianggap tidak login
+func ExtractTokenAdminId(e echo.Context) int {
+	return extractTokenId(e, AudienceAdmin)
+}
+
+// extract ID session dari token jwt, dipakai untuk logout
+func ExtractTokenSessionId(e echo.Context) string {
+	for _, audience := range []string{AudienceUser, AudienceAdmin} {
+		if claims := extractClaims(e, audience); claims != nil {
+			sessionId, _ := claims["sid"].(string)
+			return sessionId
+		}
+	}
+	return ""
+}
+
+func extractTokenId(e echo.Context, audience string) int {
+	claims := extractClaims(e, audience)
+	if claims == nil {
+		return 0
+	}
+
+	userId, isValidUserId := claims["userId"].(float64)
+	if !isValidUserId {
+		return 0
+	}
+	return int(userId)
+}
+
+// extractClaims memakai token yang sudah divalidasi JWT middleware jika ada,
+// sehingga session tidak perlu dicek ulang dalam satu request.
+func extractClaims(e echo.Context, audience string) jwt.MapClaims {
+	if tokenJWT, ok := e.Get("user").(*jwt.Token); ok {
+		if hasAudience(tokenJWT, audience) {
+			return tokenJWT.Claims.(jwt.MapClaims)
+		}
+		return nil
+	}
+
 	header := e.Request().Header.Get("Authorization")
 	headerToken := strings.Split(header, " ")
 	token := headerToken[len(headerToken)-1]
-	tokenJWT, _ := jwt.Parse(token, func(t *jwt.Token) (interface{}, error) {
-		return []byte(config.JWT_SECRET), nil
-	})
+	tokenJWT, err := parseToken(token, audience)
+	if err != nil {
+		return nil
+	}
+	return tokenJWT.Claims.(jwt.MapClaims)
+}
 
-	if tokenJWT.Valid {
-		claims := tokenJWT.Claims.(jwt.MapClaims)
-		userId, isValidUserId := claims["userId"].(float64)
-		if !isValidUserId {
-			return 0
+func hasAudience(tokenJWT *jwt.Token, audience string) bool {
+	audiences, err := tokenJWT.Claims.GetAudience()
+	if err != nil {
+		return false
+	}
+	for _, aud := range audiences {
+		if aud == audience {
+			return true
 		}
-		return int(userId)
 	}
-	return 0
+	return false
 }
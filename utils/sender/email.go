@@ -0,0 +1,52 @@
+package sender
+
+import (
+	"errors"
+	"fmt"
+	"net/smtp"
+	"strings"
+)
+
+type EmailSender struct {
+	host     string
+	port     int
+	username string
+	password string
+	from     string
+}
+
+func NewEmail(host string, port int, username, password, from string) *EmailSender {
+	return &EmailSender{
+		host:     host,
+		port:     port,
+		username: username,
+		password: password,
+		from:     from,
+	}
+}
+
+// Send implements SenderInterface.
+func (es *EmailSender) Send(msg Message) error {
+	if msg.Email == "" {
+		return errors.New("email tujuan kosong")
+	}
+
+	var body strings.Builder
+	body.WriteString("From: " + es.from + "\r\n")
+	body.WriteString("To: " + msg.Email + "\r\n")
+	body.WriteString("Subject: " + msg.Subject + "\r\n")
+	body.WriteString("MIME-Version: 1.0\r\n")
+	body.WriteString("Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+	body.WriteString(msg.Body)
+
+	var auth smtp.Auth
+	if es.username != "" {
+		auth = smtp.PlainAuth("", es.username, es.password, es.host)
+	}
+
+	addr := fmt.Sprintf("%s:%d", es.host, es.port)
+	if err := smtp.SendMail(addr, auth, es.from, []string{msg.Email}, []byte(body.String())); err != nil {
+		return fmt.Errorf("error sending email: %w", err)
+	}
+	return nil
+}
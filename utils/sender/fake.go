@@ -0,0 +1,16 @@
+package sender
+
+import "log"
+
+// FakeSender hanya mencatat pesan ke log, dipakai untuk pengembangan lokal.
+type FakeSender struct{}
+
+func NewFake() *FakeSender {
+	return &FakeSender{}
+}
+
+// Send implements SenderInterface.
+func (fs *FakeSender) Send(msg Message) error {
+	log.Printf("[fake sender] ke %s / %s - %s: %s", msg.Phone, msg.Email, msg.Subject, msg.Body)
+	return nil
+}
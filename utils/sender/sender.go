@@ -0,0 +1,78 @@
+package sender
+
+import (
+	"errors"
+	"fmt"
+	"jastip-jakarta/app/config"
+	"strconv"
+	"strings"
+)
+
+// Message dikirim ke nomor WhatsApp dan/atau email penerima, setiap driver
+// memakai tujuan yang sesuai dengan salurannya.
+type Message struct {
+	Phone   string
+	Email   string
+	Subject string
+	Body    string
+}
+
+type SenderInterface interface {
+	Send(msg Message) error
+}
+
+// New memilih driver pengirim pesan berdasarkan config.SENDER_DRIVER.
+// Beberapa driver bisa dipakai sekaligus dengan memisahkannya dengan koma, misalnya "whatsapp,email".
+func New() (SenderInterface, error) {
+	var senders []SenderInterface
+	for _, driver := range strings.Split(config.SENDER_DRIVER, ",") {
+		switch strings.TrimSpace(driver) {
+		case "fake":
+			senders = append(senders, NewFake())
+		case "whatsapp":
+			senders = append(senders, NewWhatsApp(config.WHATSAPP_API_URL, config.WHATSAPP_API_TOKEN))
+		case "email":
+			senders = append(senders, NewEmail(config.SMTP_HOST, config.SMTP_PORT, config.SMTP_USERNAME, config.SMTP_PASSWORD, config.SMTP_FROM))
+		default:
+			return nil, fmt.Errorf("driver pengirim pesan tidak dikenal: %s", driver)
+		}
+	}
+
+	if len(senders) == 1 {
+		return senders[0], nil
+	}
+	return &multiSender{senders: senders}, nil
+}
+
+// multiSender mengirim pesan lewat semua driver. Pengiriman dianggap berhasil
+// jika minimal satu driver berhasil.
+type multiSender struct {
+	senders []SenderInterface
+}
+
+// Send implements SenderInterface.
+func (ms *multiSender) Send(msg Message) error {
+	var errs []error
+	for _, sender := range ms.senders {
+		if err := sender.Send(msg); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) == len(ms.senders) {
+		return errors.Join(errs...)
+	}
+	return nil
+}
+
+// FormatPhoneNumber mengubah nomor telepon yang tersimpan sebagai angka, misalnya 81234567890,
+// menjadi format internasional 6281234567890.
+func FormatPhoneNumber(phoneNumber int) string {
+	if phoneNumber == 0 {
+		return ""
+	}
+	phone := strconv.Itoa(phoneNumber)
+	if strings.HasPrefix(phone, "62") {
+		return phone
+	}
+	return "62" + phone
+}
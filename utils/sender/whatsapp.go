@@ -0,0 +1,59 @@
+package sender
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// WhatsAppSender mengirim pesan lewat HTTP API gateway WhatsApp yang menerima
+// JSON {"phone": ..., "message": ...} dengan token pada header Authorization.
+type WhatsAppSender struct {
+	apiURL   string
+	apiToken string
+	client   *http.Client
+}
+
+func NewWhatsApp(apiURL, apiToken string) *WhatsAppSender {
+	return &WhatsAppSender{
+		apiURL:   apiURL,
+		apiToken: apiToken,
+		client:   &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Send implements SenderInterface.
+func (ws *WhatsAppSender) Send(msg Message) error {
+	if msg.Phone == "" {
+		return errors.New("nomor WhatsApp tujuan kosong")
+	}
+
+	body, err := json.Marshal(map[string]string{
+		"phone":   msg.Phone,
+		"message": msg.Body,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, ws.apiURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", ws.apiToken)
+
+	resp, err := ws.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("error sending WhatsApp: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("error sending WhatsApp: status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,184 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/cloudinary/cloudinary-go/v2"
+	"github.com/cloudinary/cloudinary-go/v2/api"
+	"github.com/cloudinary/cloudinary-go/v2/api/admin"
+	"github.com/cloudinary/cloudinary-go/v2/api/uploader"
+)
+
+// masa berlaku URL privat yang dipakai aplikasi sendiri untuk mengunduh file (Get)
+const privateDownloadExpiry = time.Minute
+
+type CloudinaryStorage struct {
+	cld *cloudinary.Cloudinary
+}
+
+func NewCloudinary(cloudinaryURL string) (StorageInterface, error) {
+	cld, err := cloudinary.NewFromURL(cloudinaryURL)
+	if err != nil {
+		return nil, err
+	}
+	return &CloudinaryStorage{
+		cld: cld,
+	}, nil
+}
+
+// publicID membuang ekstensi dari key karena Cloudinary menyimpan format gambar terpisah.
+func publicID(key string) string {
+	return strings.TrimSuffix(key, path.Ext(key))
+}
+
+// deliveryType memakai tipe authenticated untuk key privat sehingga hanya bisa dibuka lewat URL bertanda tangan.
+func deliveryType(key string) string {
+	if IsPrivateKey(key) {
+		return "authenticated"
+	}
+	return "upload"
+}
+
+// Put implements StorageInterface.
+func (cs *CloudinaryStorage) Put(key string, body io.Reader, size int64, contentType string) (string, error) {
+	resp, err := cs.cld.Upload.Upload(context.Background(), body, uploader.UploadParams{
+		PublicID:  publicID(key),
+		Type:      api.DeliveryType(deliveryType(key)),
+		Overwrite: api.Bool(true),
+	})
+	if err != nil {
+		return "", fmt.Errorf("error uploading to Cloudinary: %w", err)
+	}
+	if resp.Error.Message != "" {
+		return "", fmt.Errorf("error uploading to Cloudinary: %s", resp.Error.Message)
+	}
+	return resp.SecureURL, nil
+}
+
+// Get implements StorageInterface.
+func (cs *CloudinaryStorage) Get(key string) (io.ReadCloser, error) {
+	url, err := cs.SignedURL(key, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := http.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("file tidak ditemukan: %s", key)
+	}
+	return resp.Body, nil
+}
+
+// Delete implements StorageInterface.
+func (cs *CloudinaryStorage) Delete(key string) error {
+	resp, err := cs.cld.Upload.Destroy(context.Background(), uploader.DestroyParams{
+		PublicID: publicID(key),
+		Type:     deliveryType(key),
+	})
+	if err != nil {
+		return err
+	}
+	if resp.Error.Message != "" {
+		return fmt.Errorf("error deleting from Cloudinary: %s", resp.Error.Message)
+	}
+	return nil
+}
+
+// SignedURL implements StorageInterface.
+// Key privat memakai private download URL yang memiliki expires_at, karena URL delivery
+// bertanda tangan Cloudinary tidak memiliki masa berlaku.
+func (cs *CloudinaryStorage) SignedURL(key string, expiry time.Duration) (string, error) {
+	if IsPrivateKey(key) {
+		if expiry <= 0 {
+			expiry = privateDownloadExpiry
+		}
+		expiresAt := time.Now().Add(expiry)
+		return cs.cld.Upload.PrivateDownloadURL(uploader.PrivateDownloadURLParams{
+			PublicID:     publicID(key),
+			Format:       strings.TrimPrefix(path.Ext(key), "."),
+			DeliveryType: deliveryType(key),
+			ExpiresAt:    &expiresAt,
+		})
+	}
+
+	image, err := cs.cld.Image(publicID(key))
+	if err != nil {
+		return "", err
+	}
+	image.DeliveryType = api.DeliveryType(deliveryType(key))
+	image.Config.URL.Secure = true
+	image.Config.URL.SignURL = true
+
+	url, err := image.String()
+	if err != nil {
+		return "", err
+	}
+	if ext := strings.TrimPrefix(path.Ext(key), "."); ext != "" {
+		url += "." + ext
+	}
+	return url, nil
+}
+
+// List implements StorageInterface.
+func (cs *CloudinaryStorage) List(prefix string) ([]Object, error) {
+	var objects []Object
+	for _, delivery := range []string{"upload", "authenticated"} {
+		params := admin.AssetsParams{
+			AssetType:    api.Image,
+			DeliveryType: delivery,
+			Prefix:       prefix,
+			MaxResults:   500,
+		}
+
+		for {
+			resp, err := cs.cld.Admin.Assets(context.Background(), params)
+			if err != nil {
+				return nil, err
+			}
+			if resp.Error.Message != "" {
+				return nil, fmt.Errorf("error listing Cloudinary assets: %s", resp.Error.Message)
+			}
+
+			for _, asset := range resp.Assets {
+				objects = append(objects, Object{Key: asset.PublicID + "." + asset.Format, LastModified: asset.CreatedAt})
+			}
+
+			if resp.NextCursor == "" {
+				break
+			}
+			params.NextCursor = resp.NextCursor
+		}
+	}
+	return objects, nil
+}
+
+// cloudinaryURLPattern mengambil public ID dan ekstensi dari URL delivery Cloudinary,
+// dengan atau tanpa signature dan versi.
+var cloudinaryURLPattern = regexp.MustCompile(`/image/(?:upload|authenticated)/(?:s--[^/]+--/)?(?:v\d+/)?(.+)$`)
+
+// KeyFromURL implements StorageInterface.
+func (cs *CloudinaryStorage) KeyFromURL(url string) (string, bool) {
+	if i := strings.Index(url, "?"); i >= 0 {
+		url = url[:i]
+	}
+	if !strings.Contains(url, "res.cloudinary.com/"+cs.cld.Config.Cloud.CloudName+"/") {
+		return "", false
+	}
+
+	match := cloudinaryURLPattern.FindStringSubmatch(url)
+	if match == nil {
+		return "", false
+	}
+	return match[1], true
+}
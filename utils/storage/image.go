@@ -0,0 +1,213 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/jpeg"
+	"image/png"
+
+	"golang.org/x/image/draw"
+)
+
+// batas jumlah piksel gambar asli untuk mencegah gambar yang sangat besar menghabiskan memori
+const maxSourcePixels = 50_000_000
+
+type processedImage struct {
+	data        []byte
+	ext         string
+	contentType string
+}
+
+// detectImageType memeriksa magic bytes file dan mengembalikan ekstensi serta content type.
+func detectImageType(data []byte) (string, string, error) {
+	switch {
+	case bytes.HasPrefix(data, []byte{0xFF, 0xD8, 0xFF}):
+		return ".jpg", "image/jpeg", nil
+	case bytes.HasPrefix(data, []byte{0x89, 'P', 'N', 'G', '\r', '\n', 0x1A, '\n'}):
+		return ".png", "image/png", nil
+	default:
+		return "", "", errors.New("file bukan gambar jpg atau png")
+	}
+}
+
+// processImage men-decode gambar lalu mengubah ukurannya maksimal maxDimension piksel di sisi terpanjang.
+// Encode ulang membuang seluruh metadata EXIF termasuk lokasi GPS, sehingga orientasi EXIF diterapkan lebih dulu.
+func processImage(data []byte, maxDimension, quality int) (processedImage, image.Image, error) {
+	ext, contentType, err := detectImageType(data)
+	if err != nil {
+		return processedImage{}, nil, err
+	}
+
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return processedImage{}, nil, errors.New("gambar tidak dapat dibaca")
+	}
+	if cfg.Width*cfg.Height > maxSourcePixels {
+		return processedImage{}, nil, errors.New("resolusi gambar terlalu besar")
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return processedImage{}, nil, errors.New("gambar tidak dapat dibaca")
+	}
+
+	img = resizeToFit(img, maxDimension)
+	if ext == ".jpg" {
+		img = applyOrientation(img, jpegOrientation(data))
+	}
+
+	encoded, err := encodeImage(img, ext, quality)
+	if err != nil {
+		return processedImage{}, nil, err
+	}
+	return processedImage{data: encoded, ext: ext, contentType: contentType}, img, nil
+}
+
+// thumbnail membuat versi kecil dari gambar yang sudah diproses.
+func thumbnail(img image.Image, size, quality int, ext, contentType string) (processedImage, error) {
+	encoded, err := encodeImage(resizeToFit(img, size), ext, quality)
+	if err != nil {
+		return processedImage{}, err
+	}
+	return processedImage{data: encoded, ext: ext, contentType: contentType}, nil
+}
+
+func encodeImage(img image.Image, ext string, quality int) ([]byte, error) {
+	var buf bytes.Buffer
+	var err error
+	if ext == ".png" {
+		encoder := png.Encoder{CompressionLevel: png.BestCompression}
+		err = encoder.Encode(&buf, img)
+	} else {
+		err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: quality})
+	}
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func resizeToFit(img image.Image, maxDimension int) image.Image {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+
+	if maxDimension <= 0 || (width <= maxDimension && height <= maxDimension) {
+		return img
+	}
+
+	if width >= height {
+		height = height * maxDimension / width
+		width = maxDimension
+	} else {
+		width = width * maxDimension / height
+		height = maxDimension
+	}
+	if width < 1 {
+		width = 1
+	}
+	if height < 1 {
+		height = 1
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), img, bounds, draw.Src, nil)
+	return dst
+}
+
+// applyOrientation memutar atau membalik gambar sesuai tag Orientation EXIF (1-8).
+func applyOrientation(img image.Image, orientation int) image.Image {
+	if orientation < 2 || orientation > 8 {
+		return img
+	}
+
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	dstWidth, dstHeight := width, height
+	if orientation >= 5 {
+		dstWidth, dstHeight = height, width
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dstWidth, dstHeight))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			var dx, dy int
+			switch orientation {
+			case 2:
+				dx, dy = width-1-x, y
+			case 3:
+				dx, dy = width-1-x, height-1-y
+			case 4:
+				dx, dy = x, height-1-y
+			case 5:
+				dx, dy = y, x
+			case 6:
+				dx, dy = height-1-y, x
+			case 7:
+				dx, dy = height-1-y, width-1-x
+			case 8:
+				dx, dy = y, width-1-x
+			}
+			dst.Set(dx, dy, img.At(bounds.Min.X+x, bounds.Min.Y+y))
+		}
+	}
+	return dst
+}
+
+// jpegOrientation membaca tag Orientation dari segmen APP1 Exif. Mengembalikan 1 jika tidak ada.
+func jpegOrientation(data []byte) int {
+	pos := 2
+	for pos+4 <= len(data) {
+		if data[pos] != 0xFF {
+			return 1
+		}
+		marker := data[pos+1]
+		length := int(binary.BigEndian.Uint16(data[pos+2 : pos+4]))
+		if marker == 0xDA || length < 2 || pos+2+length > len(data) {
+			return 1
+		}
+
+		segment := data[pos+4 : pos+2+length]
+		if marker == 0xE1 && bytes.HasPrefix(segment, []byte("Exif\x00\x00")) {
+			return exifOrientation(segment[6:])
+		}
+		pos += 2 + length
+	}
+	return 1
+}
+
+func exifOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 1
+	}
+
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+
+	// offset dibandingkan sebagai uint32 agar tidak menjadi negatif di platform 32-bit
+	offset32 := order.Uint32(tiff[4:8])
+	if uint64(offset32)+2 > uint64(len(tiff)) {
+		return 1
+	}
+	offset := int(offset32)
+
+	entries := int(order.Uint16(tiff[offset : offset+2]))
+	for i := 0; i < entries; i++ {
+		entry := offset + 2 + i*12
+		if entry+12 > len(tiff) {
+			return 1
+		}
+		if order.Uint16(tiff[entry:entry+2]) == 0x0112 {
+			return int(order.Uint16(tiff[entry+8 : entry+10]))
+		}
+	}
+	return 1
+}
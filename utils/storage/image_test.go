@@ -0,0 +1,227 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"testing"
+)
+
+// tiffOrientation membuat blok TIFF dengan satu IFD berisi tag Make lalu tag Orientation.
+func tiffOrientation(order binary.ByteOrder, orientation uint16) []byte {
+	var buf bytes.Buffer
+	if order == binary.LittleEndian {
+		buf.WriteString("II")
+	} else {
+		buf.WriteString("MM")
+	}
+	binary.Write(&buf, order, uint16(42))
+	binary.Write(&buf, order, uint32(8))
+
+	binary.Write(&buf, order, uint16(2))
+	// Make, ASCII, count 0
+	binary.Write(&buf, order, []uint16{0x010F, 2})
+	binary.Write(&buf, order, []uint32{0, 0})
+	// Orientation, SHORT, count 1, nilai di 2 byte pertama field value
+	binary.Write(&buf, order, []uint16{0x0112, 3})
+	binary.Write(&buf, order, uint32(1))
+	binary.Write(&buf, order, []uint16{orientation, 0})
+	binary.Write(&buf, order, uint32(0))
+	return buf.Bytes()
+}
+
+// segment membuat segmen JPEG dengan panjang yang dihitung dari payload.
+func segment(marker byte, payload []byte) []byte {
+	seg := []byte{0xFF, marker, 0, 0}
+	binary.BigEndian.PutUint16(seg[2:], uint16(len(payload)+2))
+	return append(seg, payload...)
+}
+
+func exifSegment(tiff []byte) []byte {
+	return segment(0xE1, append([]byte("Exif\x00\x00"), tiff...))
+}
+
+// jpegWith menyusun header JPEG: SOI, segmen yang diberikan, lalu SOS dan EOI.
+func jpegWith(segments ...[]byte) []byte {
+	data := []byte{0xFF, 0xD8}
+	for _, seg := range segments {
+		data = append(data, seg...)
+	}
+	return append(data, 0xFF, 0xDA, 0x00, 0x02, 0xFF, 0xD9)
+}
+
+func TestJpegOrientation(t *testing.T) {
+	for _, order := range []binary.ByteOrder{binary.LittleEndian, binary.BigEndian} {
+		for orientation := uint16(1); orientation <= 8; orientation++ {
+			data := jpegWith(segment(0xE0, []byte("JFIF\x00")), exifSegment(tiffOrientation(order, orientation)))
+			if got := jpegOrientation(data); got != int(orientation) {
+				t.Errorf("%v orientation %d: got %d", order, orientation, got)
+			}
+		}
+	}
+}
+
+func TestJpegOrientationMalformed(t *testing.T) {
+	valid := tiffOrientation(binary.BigEndian, 6)
+
+	hugeOffset := append([]byte(nil), valid...)
+	binary.BigEndian.PutUint32(hugeOffset[4:8], 0xFFFFFFFF)
+
+	manyEntries := append([]byte(nil), valid...)
+	binary.BigEndian.PutUint16(manyEntries[8:10], 0xFFFF)
+	manyEntries = manyEntries[:len(manyEntries)-16]
+
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"tanpa segmen", jpegWith()},
+		{"hanya SOI", []byte{0xFF, 0xD8}},
+		{"marker tidak diawali 0xFF", append([]byte{0xFF, 0xD8, 0x00, 0xE1, 0x00, 0x10}, make([]byte, 16)...)},
+		{"panjang segmen kurang dari 2", []byte{0xFF, 0xD8, 0xFF, 0xE1, 0x00, 0x01, 0x00}},
+		{"panjang segmen melebihi data", []byte{0xFF, 0xD8, 0xFF, 0xE1, 0x01, 0x00, 'E', 'x', 'i', 'f'}},
+		{"APP1 bukan Exif", jpegWith(segment(0xE1, []byte("http://ns.adobe.com/xap/1.0/\x00")))},
+		{"Exif setelah SOS diabaikan", append(jpegWith(), exifSegment(valid)...)},
+		{"TIFF terlalu pendek", jpegWith(exifSegment([]byte("MM\x00\x2A")))},
+		{"byte order tidak dikenal", jpegWith(exifSegment(append([]byte("XX"), valid[2:]...)))},
+		{"offset IFD di luar data", jpegWith(exifSegment(hugeOffset))},
+		{"jumlah entry melebihi data", jpegWith(exifSegment(manyEntries))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := jpegOrientation(tt.data); got != 1 {
+				t.Errorf("got %d, want 1", got)
+			}
+		})
+	}
+}
+
+func TestJpegOrientationTruncated(t *testing.T) {
+	for _, order := range []binary.ByteOrder{binary.LittleEndian, binary.BigEndian} {
+		data := jpegWith(exifSegment(tiffOrientation(order, 6)))
+		for size := 0; size < len(data); size++ {
+			got := jpegOrientation(data[:size])
+			if got != 1 && got != 6 {
+				t.Errorf("%v dipotong %d byte: got %d", order, size, got)
+			}
+		}
+	}
+}
+
+func TestExifOrientationTruncatedIFD(t *testing.T) {
+	for _, order := range []binary.ByteOrder{binary.LittleEndian, binary.BigEndian} {
+		tiff := tiffOrientation(order, 8)
+		for size := 0; size < len(tiff); size++ {
+			// tag Orientation berakhir di byte ke-34, sebelum itu harus jatuh ke nilai default
+			want := 1
+			if size >= 34 {
+				want = 8
+			}
+			if got := exifOrientation(tiff[:size]); got != want {
+				t.Errorf("%v dipotong %d byte: got %d, want %d", order, size, got, want)
+			}
+		}
+	}
+}
+
+func TestResizeToFit(t *testing.T) {
+	tests := []struct {
+		name          string
+		width, height int
+		maxDimension  int
+		wantW, wantH  int
+	}{
+		{"lebih kecil dari batas", 800, 600, 1600, 800, 600},
+		{"sama dengan batas", 1600, 1200, 1600, 1600, 1200},
+		{"landscape", 4000, 3000, 1600, 1600, 1200},
+		{"portrait", 3000, 4000, 1600, 1200, 1600},
+		{"persegi", 2000, 2000, 1000, 1000, 1000},
+		{"sangat lebar tetap minimal 1 piksel", 5000, 1, 100, 100, 1},
+		{"sangat tinggi tetap minimal 1 piksel", 1, 5000, 100, 1, 100},
+		{"batas 0 berarti tidak diubah", 4000, 3000, 0, 4000, 3000},
+		{"batas negatif berarti tidak diubah", 4000, 3000, -1, 4000, 3000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			img := image.NewRGBA(image.Rect(0, 0, tt.width, tt.height))
+			got := resizeToFit(img, tt.maxDimension).Bounds()
+			if got.Dx() != tt.wantW || got.Dy() != tt.wantH {
+				t.Errorf("got %dx%d, want %dx%d", got.Dx(), got.Dy(), tt.wantW, tt.wantH)
+			}
+		})
+	}
+}
+
+func TestResizeToFitKeepsSmallImage(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 10, 10))
+	if resizeToFit(img, 100) != image.Image(img) {
+		t.Error("gambar yang sudah cukup kecil tidak boleh diproses ulang")
+	}
+}
+
+func TestResizeToFitOffsetBounds(t *testing.T) {
+	img := image.NewRGBA(image.Rect(50, 50, 450, 250))
+	got := resizeToFit(img, 100).Bounds()
+	if got != image.Rect(0, 0, 100, 50) {
+		t.Errorf("got %v", got)
+	}
+}
+
+func TestApplyOrientation(t *testing.T) {
+	// gambar 3x2 dengan penanda di pojok kiri atas
+	marked := color.RGBA{R: 255, A: 255}
+	src := image.NewRGBA(image.Rect(0, 0, 3, 2))
+	src.Set(0, 0, marked)
+
+	tests := []struct {
+		orientation  int
+		wantW, wantH int
+		markX, markY int
+	}{
+		{1, 3, 2, 0, 0},
+		{2, 3, 2, 2, 0},
+		{3, 3, 2, 2, 1},
+		{4, 3, 2, 0, 1},
+		{5, 2, 3, 0, 0},
+		{6, 2, 3, 1, 0},
+		{7, 2, 3, 1, 2},
+		{8, 2, 3, 0, 2},
+		{0, 3, 2, 0, 0},
+		{9, 3, 2, 0, 0},
+	}
+	for _, tt := range tests {
+		got := applyOrientation(src, tt.orientation)
+		bounds := got.Bounds()
+		if bounds.Dx() != tt.wantW || bounds.Dy() != tt.wantH {
+			t.Errorf("orientation %d: got %dx%d, want %dx%d", tt.orientation, bounds.Dx(), bounds.Dy(), tt.wantW, tt.wantH)
+			continue
+		}
+		if got.At(tt.markX, tt.markY) != color.Color(marked) {
+			t.Errorf("orientation %d: penanda tidak ada di (%d,%d)", tt.orientation, tt.markX, tt.markY)
+		}
+	}
+}
+
+func TestProcessImageAppliesOrientation(t *testing.T) {
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 40, 20)), nil); err != nil {
+		t.Fatal(err)
+	}
+	encoded := buf.Bytes()
+	// sisipkan segmen Exif tepat setelah SOI
+	data := append([]byte{0xFF, 0xD8}, exifSegment(tiffOrientation(binary.LittleEndian, 6))...)
+	data = append(data, encoded[2:]...)
+
+	result, img, err := processImage(data, 1600, 80)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bounds := img.Bounds(); bounds.Dx() != 20 || bounds.Dy() != 40 {
+		t.Errorf("got %dx%d, want 20x40", bounds.Dx(), bounds.Dy())
+	}
+	if jpegOrientation(result.data) != 1 {
+		t.Error("hasil encode ulang tidak boleh membawa metadata Exif")
+	}
+}
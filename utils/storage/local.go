@@ -0,0 +1,161 @@
+package storage
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type LocalStorage struct {
+	dir     string
+	baseURL string
+	secret  string
+}
+
+func NewLocal(dir, baseURL, secret string) *LocalStorage {
+	return &LocalStorage{
+		dir:     dir,
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		secret:  secret,
+	}
+}
+
+// filePath memetakan key ke path di dalam dir dan menolak key yang keluar dari dir.
+func (ls *LocalStorage) filePath(key string) (string, error) {
+	cleaned := path.Clean("/" + key)
+	if cleaned == "/" {
+		return "", errors.New("key tidak valid")
+	}
+	return filepath.Join(ls.dir, filepath.FromSlash(cleaned)), nil
+}
+
+// Put implements StorageInterface.
+func (ls *LocalStorage) Put(key string, body io.Reader, size int64, contentType string) (string, error) {
+	fullPath, err := ls.filePath(key)
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(fullPath), 0o755); err != nil {
+		return "", err
+	}
+
+	file, err := os.Create(fullPath)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	if _, err := io.Copy(file, body); err != nil {
+		return "", err
+	}
+	return ls.baseURL + "/" + key, nil
+}
+
+// Get implements StorageInterface.
+func (ls *LocalStorage) Get(key string) (io.ReadCloser, error) {
+	fullPath, err := ls.filePath(key)
+	if err != nil {
+		return nil, err
+	}
+	return os.Open(fullPath)
+}
+
+// Delete implements StorageInterface.
+func (ls *LocalStorage) Delete(key string) error {
+	fullPath, err := ls.filePath(key)
+	if err != nil {
+		return err
+	}
+	err = os.Remove(fullPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// List implements StorageInterface.
+func (ls *LocalStorage) List(prefix string) ([]Object, error) {
+	var objects []Object
+	err := filepath.Walk(ls.dir, func(fullPath string, info os.FileInfo, err error) error {
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(ls.dir, fullPath)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(rel)
+		if strings.HasPrefix(key, prefix) {
+			objects = append(objects, Object{Key: key, LastModified: info.ModTime()})
+		}
+		return nil
+	})
+	return objects, err
+}
+
+// KeyFromURL implements StorageInterface.
+func (ls *LocalStorage) KeyFromURL(url string) (string, bool) {
+	if i := strings.Index(url, "?"); i >= 0 {
+		url = url[:i]
+	}
+	key := strings.TrimPrefix(url, ls.baseURL+"/")
+	return key, key != url && key != ""
+}
+
+// SignedURL implements StorageInterface.
+func (ls *LocalStorage) SignedURL(key string, expiry time.Duration) (string, error) {
+	expires := strconv.FormatInt(time.Now().Add(expiry).Unix(), 10)
+	return ls.baseURL + "/" + key + "?expires=" + expires + "&signature=" + ls.sign(key, expires), nil
+}
+
+func (ls *LocalStorage) sign(key, expires string) string {
+	mac := hmac.New(sha256.New, []byte(ls.secret))
+	mac.Write([]byte(key + ":" + expires))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// ServeHTTP menyajikan file dari dir. File privat dan URL yang membawa signature
+// hanya disajikan jika signature valid dan belum kedaluwarsa.
+func (ls *LocalStorage) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	key := strings.TrimPrefix(r.URL.Path, "/")
+
+	// key yang tidak kanonis (misalnya "a//private/x" atau "a/./private/x") ditolak agar
+	// pengecekan folder privat dan signature selalu memakai key yang sama dengan file di disk
+	if key != strings.TrimPrefix(path.Clean("/"+key), "/") {
+		http.NotFound(w, r)
+		return
+	}
+
+	if signature := r.URL.Query().Get("signature"); signature != "" || IsPrivateKey(key) {
+		expires := r.URL.Query().Get("expires")
+		expiresAt, err := strconv.ParseInt(expires, 10, 64)
+		if err != nil || time.Now().Unix() > expiresAt || !hmac.Equal([]byte(signature), []byte(ls.sign(key, expires))) {
+			http.Error(w, "url tidak valid atau sudah kedaluwarsa", http.StatusForbidden)
+			return
+		}
+	}
+
+	fullPath, err := ls.filePath(key)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	http.ServeFile(w, r, fullPath)
+}
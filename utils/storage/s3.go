@@ -0,0 +1,96 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+type S3Storage struct {
+	client *minio.Client
+	bucket string
+}
+
+func NewS3(endpoint, accessKey, secretKey, bucket string, useSSL bool) (StorageInterface, error) {
+	client, err := minio.New(endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(accessKey, secretKey, ""),
+		Secure: useSSL,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &S3Storage{
+		client: client,
+		bucket: bucket,
+	}, nil
+}
+
+// Put implements StorageInterface.
+func (ss *S3Storage) Put(key string, body io.Reader, size int64, contentType string) (string, error) {
+	_, err := ss.client.PutObject(context.Background(), ss.bucket, key, body, size, minio.PutObjectOptions{
+		ContentType: contentType,
+	})
+	if err != nil {
+		return "", fmt.Errorf("error uploading to S3: %w", err)
+	}
+
+	return ss.baseURL() + key, nil
+}
+
+func (ss *S3Storage) baseURL() string {
+	endpoint := ss.client.EndpointURL().String()
+	return strings.TrimSuffix(endpoint, "/") + "/" + ss.bucket + "/"
+}
+
+// Get implements StorageInterface.
+func (ss *S3Storage) Get(key string) (io.ReadCloser, error) {
+	object, err := ss.client.GetObject(context.Background(), ss.bucket, key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if _, err := object.Stat(); err != nil {
+		object.Close()
+		return nil, err
+	}
+	return object, nil
+}
+
+// Delete implements StorageInterface.
+func (ss *S3Storage) Delete(key string) error {
+	return ss.client.RemoveObject(context.Background(), ss.bucket, key, minio.RemoveObjectOptions{})
+}
+
+// SignedURL implements StorageInterface.
+func (ss *S3Storage) SignedURL(key string, expiry time.Duration) (string, error) {
+	url, err := ss.client.PresignedGetObject(context.Background(), ss.bucket, key, expiry, nil)
+	if err != nil {
+		return "", err
+	}
+	return url.String(), nil
+}
+
+// List implements StorageInterface.
+func (ss *S3Storage) List(prefix string) ([]Object, error) {
+	var objects []Object
+	for object := range ss.client.ListObjects(context.Background(), ss.bucket, minio.ListObjectsOptions{Prefix: prefix, Recursive: true}) {
+		if object.Err != nil {
+			return nil, object.Err
+		}
+		objects = append(objects, Object{Key: object.Key, LastModified: object.LastModified})
+	}
+	return objects, nil
+}
+
+// KeyFromURL implements StorageInterface.
+func (ss *S3Storage) KeyFromURL(url string) (string, bool) {
+	if i := strings.Index(url, "?"); i >= 0 {
+		url = url[:i]
+	}
+	key := strings.TrimPrefix(url, ss.baseURL())
+	return key, key != url && key != ""
+}
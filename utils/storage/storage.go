@@ -0,0 +1,215 @@
+package storage
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"jastip-jakarta/app/config"
+	"mime/multipart"
+	"path"
+	"strings"
+	"time"
+)
+
+// folder tempat semua upload disimpan, dipertahankan dari folder Cloudinary lama
+const uploadFolder = "BE20_MyEcommerce"
+
+// foto di dalam folder ini hanya bisa diakses lewat signed URL
+const privateFolder = uploadFolder + "/private"
+
+type StorageInterface interface {
+	Put(key string, body io.Reader, size int64, contentType string) (string, error)
+	Get(key string) (io.ReadCloser, error)
+	Delete(key string) error
+	SignedURL(key string, expiry time.Duration) (string, error)
+	List(prefix string) ([]Object, error)
+	KeyFromURL(url string) (string, bool)
+}
+
+type Object struct {
+	Key          string
+	LastModified time.Time
+}
+
+// UploadPrefix adalah prefix key semua gambar yang diupload aplikasi.
+func UploadPrefix() string {
+	return uploadFolder + "/"
+}
+
+// IsPrivateKey mengecek apakah key berada di folder privat.
+func IsPrivateKey(key string) bool {
+	return strings.HasPrefix(key, privateFolder+"/")
+}
+
+// New memilih driver penyimpanan berdasarkan config.STORAGE_DRIVER.
+func New() (StorageInterface, error) {
+	switch config.STORAGE_DRIVER {
+	case "cloudinary":
+		return NewCloudinary(config.CLD_URL)
+	case "local":
+		return NewLocal(config.STORAGE_LOCAL_DIR, config.STORAGE_BASE_URL, config.JWT_SECRET), nil
+	case "s3":
+		return NewS3(config.S3_ENDPOINT, config.S3_ACCESS_KEY, config.S3_SECRET_KEY, config.S3_BUCKET, config.S3_USE_SSL)
+	default:
+		return nil, fmt.Errorf("driver penyimpanan tidak dikenal: %s", config.STORAGE_DRIVER)
+	}
+}
+
+type UploaderInterface interface {
+	UploadImage(fileHeader *multipart.FileHeader) (string, error)
+	UploadPrivateImage(fileHeader *multipart.FileHeader) (string, error)
+	DeleteImage(ref string) error
+	SignedURL(ref string) (string, error)
+	SignedThumbnailURL(ref string) (string, error)
+}
+
+type Uploader struct {
+	storage       StorageInterface
+	maxSize       int64
+	maxDimension  int
+	quality       int
+	thumbnailSize int
+	urlExpiry     time.Duration
+}
+
+func NewUploader(storage StorageInterface) UploaderInterface {
+	return &Uploader{
+		storage:       storage,
+		maxSize:       config.UPLOAD_MAX_SIZE,
+		maxDimension:  config.IMAGE_MAX_DIMENSION,
+		quality:       config.IMAGE_QUALITY,
+		thumbnailSize: config.IMAGE_THUMBNAIL_SIZE,
+		urlExpiry:     time.Duration(config.SIGNED_URL_EXPIRY) * time.Minute,
+	}
+}
+
+// UploadImage memvalidasi, memproses dan menyimpan gambar publik beserta thumbnail-nya.
+// URL yang dikembalikan adalah URL gambar utama, URL thumbnail bisa didapat dengan ThumbnailURL.
+func (u *Uploader) UploadImage(fileHeader *multipart.FileHeader) (string, error) {
+	return u.upload(fileHeader, uploadFolder, true)
+}
+
+// UploadPrivateImage sama dengan UploadImage tetapi menyimpan gambar di folder privat
+// dan mengembalikan key objek, bukan URL. Gambar hanya bisa dibuka lewat SignedURL.
+func (u *Uploader) UploadPrivateImage(fileHeader *multipart.FileHeader) (string, error) {
+	return u.upload(fileHeader, privateFolder, false)
+}
+
+func (u *Uploader) upload(fileHeader *multipart.FileHeader, folder string, returnURL bool) (string, error) {
+	if fileHeader.Size > u.maxSize {
+		return "", fmt.Errorf("ukuran file melebihi batas %d KB", u.maxSize/1024)
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return "", fmt.Errorf("error opening file: %w", err)
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, u.maxSize+1))
+	if err != nil {
+		return "", fmt.Errorf("error reading file: %w", err)
+	}
+	if int64(len(data)) > u.maxSize {
+		return "", fmt.Errorf("ukuran file melebihi batas %d KB", u.maxSize/1024)
+	}
+
+	processed, img, err := processImage(data, u.maxDimension, u.quality)
+	if err != nil {
+		return "", err
+	}
+
+	thumb, err := thumbnail(img, u.thumbnailSize, u.quality, processed.ext, processed.contentType)
+	if err != nil {
+		return "", err
+	}
+
+	key := newKey(folder, processed.ext)
+	url, err := u.storage.Put(key, bytes.NewReader(processed.data), int64(len(processed.data)), processed.contentType)
+	if err != nil {
+		return "", fmt.Errorf("error uploading file: %w", err)
+	}
+
+	_, err = u.storage.Put(ThumbnailKey(key), bytes.NewReader(thumb.data), int64(len(thumb.data)), thumb.contentType)
+	if err != nil {
+		u.storage.Delete(key)
+		return "", fmt.Errorf("error uploading thumbnail: %w", err)
+	}
+
+	if !returnURL {
+		return key, nil
+	}
+	return url, nil
+}
+
+// KeyFromRef mengubah referensi foto yang tersimpan di database menjadi key objek.
+// Referensi bisa berupa key (foto privat) maupun URL lama (foto publik).
+func KeyFromRef(storage StorageInterface, ref string) (string, bool) {
+	if ref == "" {
+		return "", false
+	}
+	if strings.HasPrefix(ref, UploadPrefix()) {
+		return ref, true
+	}
+	return storage.KeyFromURL(ref)
+}
+
+// DeleteImage menghapus gambar beserta thumbnail-nya berdasarkan URL atau key hasil upload.
+// Referensi yang kosong atau bukan milik storage ini diabaikan.
+func (u *Uploader) DeleteImage(ref string) error {
+	key, ok := KeyFromRef(u.storage, ref)
+	if !ok {
+		return nil
+	}
+
+	if err := u.storage.Delete(key); err != nil {
+		return err
+	}
+	return u.storage.Delete(ThumbnailKey(key))
+}
+
+// SignedURL membuat URL sementara untuk URL atau key hasil upload.
+// Referensi yang bukan milik storage ini dikembalikan apa adanya.
+func (u *Uploader) SignedURL(ref string) (string, error) {
+	key, ok := KeyFromRef(u.storage, ref)
+	if !ok {
+		return ref, nil
+	}
+	return u.storage.SignedURL(key, u.urlExpiry)
+}
+
+// SignedThumbnailURL membuat URL sementara untuk thumbnail dari URL atau key hasil upload.
+func (u *Uploader) SignedThumbnailURL(ref string) (string, error) {
+	key, ok := KeyFromRef(u.storage, ref)
+	if !ok {
+		return ThumbnailURL(ref), nil
+	}
+	return u.storage.SignedURL(ThumbnailKey(key), u.urlExpiry)
+}
+
+// ThumbnailKey menurunkan key thumbnail dari key gambar utama, misalnya a/b.jpg menjadi a/b_thumb.jpg.
+func ThumbnailKey(key string) string {
+	ext := path.Ext(key)
+	return strings.TrimSuffix(key, ext) + "_thumb" + ext
+}
+
+// ThumbnailURL menurunkan URL thumbnail dari URL gambar utama hasil UploadImage.
+func ThumbnailURL(url string) string {
+	if url == "" {
+		return ""
+	}
+	if i := strings.Index(url, "?"); i >= 0 {
+		url = url[:i]
+	}
+	return ThumbnailKey(url)
+}
+
+// newKey membuat key unik di dalam folder, misalnya BE20_MyEcommerce/20240102-1a2b3c4d5e6f7a8b.jpg.
+func newKey(folder, ext string) string {
+	random := make([]byte, 8)
+	rand.Read(random)
+	name := time.Now().Format("20060102") + "-" + hex.EncodeToString(random) + ext
+	return path.Join(folder, name)
+}
@@ -22,3 +22,22 @@ func FormatDateToIndonesian(t time.Time) string {
 	ss := tgl.Format(" ", format)
 	return ss
 }
+
+// AddWorkingDays menambahkan sejumlah hari kerja ke tanggal awal,
+// melewati hari Minggu dan tanggal yang terdaftar sebagai hari libur.
+func AddWorkingDays(start time.Time, days int, holidays []time.Time) time.Time {
+	holidayMap := make(map[string]bool)
+	for _, holiday := range holidays {
+		holidayMap[holiday.Format("2006-01-02")] = true
+	}
+
+	result := start
+	for days > 0 {
+		result = result.AddDate(0, 0, 1)
+		if result.Weekday() == time.Sunday || holidayMap[result.Format("2006-01-02")] {
+			continue
+		}
+		days--
+	}
+	return result
+}